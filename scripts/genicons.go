@@ -19,11 +19,35 @@ var PRIORITY = []string{
 	"md",
 }
 
+// Other names of the issuers, which simple-icons does not know about
+var EXTRA_ALIASES = map[string]string{
+	"AWS":                 "Amazon",
+	"Amazon Web Services": "Amazon",
+	"Apple ID":            "Apple",
+	"iCloud":              "Apple",
+	"Azure":               "Microsoft",
+	"Outlook":             "Microsoft",
+	"Office 365":          "Microsoft",
+	"Microsoft 365":       "Microsoft",
+	"Google Workspace":    "Google",
+	"Meta":                "Facebook",
+	"X":                   "Twitter",
+	"PSN":                 "PlayStation",
+	"PlayStation Network": "PlayStation",
+	"Xbox Live":           "Xbox",
+	"Docker Hub":          "Docker",
+	"npmjs":               "npm",
+	"VKontakte":           "VK",
+}
+
 // Type of simple icons index
 type SimpleIcons struct {
 	Icons []struct {
-		Title string
-		Hex   string
+		Title   string
+		Hex     string
+		Aliases struct {
+			Aka []string
+		}
 	}
 }
 
@@ -35,6 +59,9 @@ type Icon struct {
 // Vendor icons
 type VendorIcons struct {
 	Icons map[string]Icon
+
+	// Other names of the issuers, mapped to the name of their icon
+	Aliases map[string]string
 }
 
 func parse_simple_icons_map() SimpleIcons {
@@ -92,7 +119,8 @@ func main() {
 	icons := parse_simple_icons_map()
 
 	vendor := VendorIcons{
-		Icons: make(map[string]Icon),
+		Icons:   make(map[string]Icon),
+		Aliases: make(map[string]string),
 	}
 
 	// Go go go
@@ -114,6 +142,21 @@ func main() {
 		}
 	}
 
+	// Add the aliases of the icons which were found
+	for _, icon := range icons.Icons {
+		if _, ok := vendor.Icons[icon.Title]; ok {
+			for _, alias := range icon.Aliases.Aka {
+				vendor.Aliases[alias] = icon.Title
+			}
+		}
+	}
+
+	for alias, title := range EXTRA_ALIASES {
+		if _, ok := vendor.Icons[title]; ok {
+			vendor.Aliases[alias] = title
+		}
+	}
+
 	// Write vendor
 	dump, _ := json.Marshal(vendor)
 	file, _ := os.Create(path.Join(OUT_DIR, "icons.json"))
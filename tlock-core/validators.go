@@ -2,7 +2,10 @@ package tlockcore
 
 import (
 	"errors"
+	"path"
 	"strings"
+
+	"github.com/eklairs/tlock/tlock-internal/paths"
 )
 
 // Error representing that the username is empty
@@ -11,8 +14,14 @@ var ERR_USERNAME_EMPTY = errors.New("Username cannot be empty")
 // Error representing that the username is empty
 var ERR_USERNAME_EXISTS = errors.New("User with that name already exists")
 
+// Error representing that the username is used by a folder of tlock itself
+var ERR_USERNAME_RESERVED = errors.New("That username is reserved by tlock")
+
+// Names of the folders which share the config directory with the ones of the users
+var reservedUsernames = []string{path.Base(paths.THEMES_DIR)}
+
 // Validates if the username is okay to be used
-// Validation is done on the basis of its emptiness and if there are other users with the same name
+// Validation is done on the basis of its emptiness, if it is reserved and if there are other users with the same name
 func (core TLockCore) validateUsername(username string) (string, error) {
 	// Strip off
 	username = strings.TrimSpace(username)
@@ -22,6 +31,13 @@ func (core TLockCore) validateUsername(username string) (string, error) {
 		return username, ERR_USERNAME_EMPTY
 	}
 
+	// Check if it is reserved, ignoring the case as the file system may do so
+	for _, reserved := range reservedUsernames {
+		if strings.EqualFold(username, reserved) {
+			return username, ERR_USERNAME_RESERVED
+		}
+	}
+
 	// Check if it already exists
 	if core.Exists(username) {
 		return username, ERR_USERNAME_EXISTS
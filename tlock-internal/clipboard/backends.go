@@ -0,0 +1,202 @@
+package clipboard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	system "github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Backend names
+const (
+	BackendAuto    = "auto"
+	BackendSystem  = "system"
+	BackendOSC52   = "osc52"
+	BackendTmux    = "tmux"
+	BackendCommand = "command"
+)
+
+// All the backends
+var BACKENDS = []string{BackendAuto, BackendSystem, BackendOSC52, BackendTmux, BackendCommand}
+
+// Error representing that the backend cannot read the clipboard
+var ERR_UNREADABLE = errors.New("Clipboard cannot be read with this backend")
+
+// Error representing that the backend is not known
+var ERR_UNKNOWN_BACKEND = errors.New("Unknown clipboard backend, must be one of auto, system, osc52, tmux or command")
+
+// Error representing that the command backend is used without a command
+var ERR_NO_COMMAND = errors.New("Clipboard backend is set to command, but clipboard_command is empty")
+
+// A way to reach the clipboard
+type backend interface {
+	// Writes the value to the clipboard
+	write(value string) error
+
+	// Reads the value from the clipboard, ERR_UNREADABLE if it cannot be read
+	read() (string, error)
+
+	// Clears the clipboard
+	clear() error
+}
+
+// Returns the backend for the given name
+func newBackend(name string, command []string) (backend, error) {
+	switch name {
+	case BackendAuto, "":
+		// The system clipboard is not there over SSH or without a display server
+		if system.Unsupported {
+			return osc52Backend{}, nil
+		}
+
+		return systemBackend{}, nil
+
+	case BackendSystem:
+		if system.Unsupported {
+			return nil, ERR_UNSUPPORTED
+		}
+
+		return systemBackend{}, nil
+
+	case BackendOSC52:
+		return osc52Backend{}, nil
+
+	case BackendTmux:
+		return tmuxBackend{}, nil
+
+	case BackendCommand:
+		if len(command) == 0 || command[0] == "" {
+			return nil, ERR_NO_COMMAND
+		}
+
+		return commandBackend{command: command}, nil
+	}
+
+	return nil, ERR_UNKNOWN_BACKEND
+}
+
+// System clipboard, through xclip, xsel, wl-clipboard, pbcopy or the Windows API
+type systemBackend struct{}
+
+func (systemBackend) write(value string) error {
+	return system.WriteAll(value)
+}
+
+func (systemBackend) read() (string, error) {
+	return system.ReadAll()
+}
+
+func (systemBackend) clear() error {
+	return system.WriteAll("")
+}
+
+// OSC 52 escape sequence, which asks the terminal to set its clipboard
+// Works over SSH as long as the local terminal supports it
+type osc52Backend struct{}
+
+// Wraps the sequence for the terminal multiplexer tlock is running in
+func (osc52Backend) wrap(sequence osc52.Sequence) osc52.Sequence {
+	if os.Getenv("TMUX") != "" {
+		return sequence.Tmux()
+	}
+
+	if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return sequence.Screen()
+	}
+
+	return sequence
+}
+
+func (backend osc52Backend) write(value string) error {
+	// Written to stderr to stay out of the way of the interface rendered on stdout
+	_, err := backend.wrap(osc52.New(value)).WriteTo(os.Stderr)
+
+	return err
+}
+
+func (osc52Backend) read() (string, error) {
+	return "", ERR_UNREADABLE
+}
+
+func (backend osc52Backend) clear() error {
+	_, err := backend.wrap(osc52.Clear()).WriteTo(os.Stderr)
+
+	return err
+}
+
+// Tmux paste buffer, which tmux also sends to the terminal clipboard if `set-clipboard` is enabled
+type tmuxBackend struct{}
+
+func (tmuxBackend) write(value string) error {
+	return run([]string{"tmux", "load-buffer", "-w", "-"}, value)
+}
+
+func (tmuxBackend) read() (string, error) {
+	return output([]string{"tmux", "save-buffer", "-"})
+}
+
+func (tmuxBackend) clear() error {
+	return run([]string{"tmux", "delete-buffer"}, "")
+}
+
+// External command which reads the value from its stdin, like `wl-copy`
+type commandBackend struct {
+	// Command along with its arguments
+	command []string
+}
+
+func (backend commandBackend) write(value string) error {
+	return run(backend.command, value)
+}
+
+func (commandBackend) read() (string, error) {
+	return "", ERR_UNREADABLE
+}
+
+func (backend commandBackend) clear() error {
+	return run(backend.command, "")
+}
+
+// Runs the command with the input on its stdin
+func run(command []string, input string) error {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return commandError(command, err, stderr)
+	}
+
+	return nil
+}
+
+// Runs the command and returns its output
+func output(command []string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", commandError(command, err, stderr)
+	}
+
+	return stdout.String(), nil
+}
+
+// Returns the error for a failed command, including what it said about the failure
+func commandError(command []string, err error, stderr bytes.Buffer) error {
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return fmt.Errorf("%s failed: %w: %s", command[0], err, message)
+	}
+
+	return fmt.Errorf("%s failed: %w", command[0], err)
+}
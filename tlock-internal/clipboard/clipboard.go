@@ -0,0 +1,129 @@
+package clipboard
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
+)
+
+// Error representing that the clipboard is not available
+var ERR_UNSUPPORTED = errors.New("Clipboard is not available")
+
+// Value written by tlock which is waiting to be cleared
+type pending struct {
+	// Value written to the clipboard
+	value string
+
+	// Incremented on every write, to tell apart the same value being copied twice
+	generation int
+}
+
+// State of the clipboard
+var (
+	// Backend in use
+	current backend = mustBackend(BackendAuto, nil)
+
+	// Last value written by tlock
+	last pending
+
+	// Guards the state
+	lock sync.Mutex
+)
+
+// Returns the backend, which never fails for auto
+func mustBackend(name string, command []string) backend {
+	backend, _ := newBackend(name, command)
+
+	return backend
+}
+
+// Sets the backend to use
+// Keeps using the automatically choosen backend if the given one cannot be used
+func Configure(name string, command []string) error {
+	backend, err := newBackend(name, command)
+
+	if err != nil {
+		backend = mustBackend(BackendAuto, nil)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	current = backend
+
+	return err
+}
+
+// Writes the value to the clipboard
+// Returns the generation of the write, which is used to clear it later
+func Write(value string) (int, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := current.write(value); err != nil {
+		return 0, err
+	}
+
+	last = pending{value: value, generation: last.generation + 1}
+
+	return last.generation, nil
+}
+
+// Reads the value from the clipboard
+// Returns ERR_UNREADABLE if the backend cannot read it
+func Read() (string, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	return current.read()
+}
+
+// Clears the clipboard if it still holds the value from the given write
+// If the backend cannot read the clipboard, it is cleared without checking
+// Returns true if the clipboard was cleared
+func ClearIfUnchanged(generation int) bool {
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Something else was copied by tlock after it, or it was already cleared
+	if last.generation != generation || last.value == "" {
+		return false
+	}
+
+	// Something else was copied by the user after it
+	if value, err := current.read(); err == nil && value != last.value {
+		return false
+	} else if err != nil && !errors.Is(err, ERR_UNREADABLE) {
+		return false
+	}
+
+	last.value = ""
+
+	return current.clear() == nil
+}
+
+// Clears the clipboard if it still holds the last value written by tlock
+// Used when tlock quits before the timeout is over
+func ClearPending() {
+	lock.Lock()
+	generation := last.generation
+	lock.Unlock()
+
+	ClearIfUnchanged(generation)
+}
+
+// Returns a command which clears the write from the clipboard after the timeout
+// Returns nil if the timeout is zero, which disables clearing
+func ClearAfter(generation int, timeout time.Duration) tea.Cmd {
+	if timeout <= 0 {
+		return nil
+	}
+
+	return tea.Tick(timeout, func(t time.Time) tea.Msg {
+		return tlockmessages.ClipboardClearedMsg{Cleared: ClearIfUnchanged(generation)}
+	})
+}
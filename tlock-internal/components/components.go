@@ -129,6 +129,79 @@ func TokenItemInactive(width int, icon, account, issuer, code string, period int
 	)
 }
 
+// === Token item ends ==
+
+// === Token row ==
+
+// Truncates the text to the width and fills the rest of the width with the spacer
+func tokenRowColumn(text string, width int, render func(string) string, spacerStyle lipgloss.Style) string {
+	text = lipgloss.NewStyle().Inline(true).MaxWidth(width).Render(text)
+
+	return render(text) + spacerStyle.Render(strings.Repeat(" ", width-lipgloss.Width(text)))
+}
+
+// Token row renderer implementation, with the issuer, account, code and time left columns
+func tokenRowImpl(width int, icon, issuer, account, code, timeLeft string, render, renderAccent func(string) string, spacerStyle, uiStyle lipgloss.Style, showIcon bool) string {
+	gap := spacerStyle.Render("  ")
+	columns := make([]string, 0)
+
+	if showIcon {
+		columns = append(columns, spacerStyle.Render(icon), gap)
+		width -= lipgloss.Width(icon) + 2
+	}
+
+	// Space left for the issuer and account name after the code and the time left
+	flexible := width - lipgloss.Width(code) - 4 - 6
+
+	// Drop the issuer name if there is not enough space
+	issuerWidth := flexible * 2 / 5
+
+	if issuerWidth < 8 {
+		issuerWidth = 0
+	}
+
+	accountWidth := max(0, flexible-issuerWidth)
+
+	if issuerWidth != 0 {
+		columns = append(columns, tokenRowColumn(issuer, issuerWidth, render, spacerStyle), gap)
+	} else {
+		accountWidth += 2
+	}
+
+	columns = append(
+		columns,
+		tokenRowColumn(account, accountWidth, renderAccent, spacerStyle), gap,
+		renderAccent(code), gap,
+		tokenRowColumn(timeLeft, 4, render, spacerStyle),
+	)
+
+	return uiStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, columns...))
+}
+
+// Active token row
+func TokenRowActive(width int, icon, account, issuer, code, timeLeft string, showIcon bool) string {
+	return tokenRowImpl(
+		width, icon, issuer, account, code, timeLeft,
+		func(text string) string { return tlockstyles.Styles.BackgroundOver.Render(text) },
+		func(text string) string {
+			return tlockstyles.Styles.BackgroundOver.Render(tlockstyles.Styles.Title.Render(text))
+		},
+		tlockstyles.Styles.BackgroundOver, tlockstyles.Styles.ListRowActive, showIcon,
+	)
+}
+
+// Inactive token row
+func TokenRowInactive(width int, icon, account, issuer, code, timeLeft string, showIcon bool) string {
+	return tokenRowImpl(
+		width, icon, issuer, account, code, timeLeft,
+		func(text string) string { return tlockstyles.Styles.SubText.Render(text) },
+		func(text string) string { return tlockstyles.Styles.SubText.Render(text) },
+		tlockstyles.Styles.SubText, tlockstyles.Styles.ListRowInactive, showIcon,
+	)
+}
+
+// === Token row ends ==
+
 // Builds a listview model devoid of every feature
 func ListViewSimple(items []list.Item, delegate list.ItemDelegate, width, height int) list.Model {
 	listview := list.New(items, delegate, width, height)
@@ -177,9 +250,7 @@ func InitializeInputBoxCustomWidth(placeholder string, width int) textinput.Mode
 }
 
 // == Folder list item
-func folderListItem(width int, name string, nameStyle lipgloss.Style, tokensCount int, tokensCountStyle, style lipgloss.Style) string {
-	bottom := fmt.Sprintf("%d tokens", tokensCount)
-
+func folderListItem(width int, name string, nameStyle lipgloss.Style, bottom string, tokensCountStyle, style lipgloss.Style) string {
 	// -6 is for including the padding
 	if len(name) > width-6 {
 		// Ellipsis
@@ -200,20 +271,52 @@ func folderListItem(width int, name string, nameStyle lipgloss.Style, tokensCoun
 	return style.Copy().Width(width).Render(strings.Join(items, "\n"))
 }
 
-// Active folder list item
-func ActiveFolderListItem(width int, name string, tokensCount int) string {
+// Active folder list item, indented by its depth in the tree of folders
+func ActiveFolderListItem(width int, name string, tokensCount, depth int) string {
+	indent := strings.Repeat("  ", depth)
+
+	return folderListItem(
+		width, indent+name, tlockstyles.Styles.Title,
+		fmt.Sprintf("%s%d tokens", indent, tokensCount), tlockstyles.Styles.SubText,
+		tlockstyles.Styles.FolderItemActive,
+	)
+}
+
+// Inactive folder list item, indented by its depth in the tree of folders
+func InactiveFolderListItem(width int, name string, tokensCount, depth int) string {
+	indent := strings.Repeat("  ", depth)
+
 	return folderListItem(
-		width, name, tlockstyles.Styles.Title,
-		tokensCount, tlockstyles.Styles.SubText,
+		width, indent+name, tlockstyles.Styles.SubText,
+		fmt.Sprintf("%s%d tokens", indent, tokensCount), tlockstyles.Styles.SubText,
+		tlockstyles.Styles.FolderItemInactive,
+	)
+}
+
+// Active smart folder list item, with its query
+func ActiveSmartFolderListItem(width int, name, query string, tokensCount int) string {
+	return folderListItem(
+		width, name, tlockstyles.Styles.Title.Copy().Italic(true),
+		fmt.Sprintf("%d tokens · %s", tokensCount, query), tlockstyles.Styles.SubText.Copy().Italic(true),
 		tlockstyles.Styles.FolderItemActive,
 	)
 }
 
-// Inactive folder list item
-func InactiveFolderListItem(width int, name string, tokensCount int) string {
+// Inactive smart folder list item, with its query
+func InactiveSmartFolderListItem(width int, name, query string, tokensCount int) string {
 	return folderListItem(
-		width, name, tlockstyles.Styles.SubText,
-		tokensCount, tlockstyles.Styles.SubText,
+		width, name, tlockstyles.Styles.SubText.Copy().Italic(true),
+		fmt.Sprintf("%d tokens · %s", tokensCount, query), tlockstyles.Styles.SubText.Copy().Italic(true),
 		tlockstyles.Styles.FolderItemInactive,
 	)
 }
+
+// Active trash list item, with where it was deleted from and when
+func ActiveTrashListItem(width int, name, details string) string {
+	return folderListItem(width, name, tlockstyles.Styles.Title, details, tlockstyles.Styles.SubText, tlockstyles.Styles.FolderItemActive)
+}
+
+// Inactive trash list item, with where it was deleted from and when
+func InactiveTrashListItem(width int, name, details string) string {
+	return folderListItem(width, name, tlockstyles.Styles.SubText, details, tlockstyles.Styles.SubText, tlockstyles.Styles.FolderItemInactive)
+}
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/constants"
+	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	tlockstyles "github.com/eklairs/tlock/tlock/styles"
 	"golang.org/x/term"
 )
@@ -26,6 +27,9 @@ type StatusBar struct {
 
 	// Current user
 	CurrentUser string
+
+	// Time at which the clipboard is cleared, zero if nothing is waiting to be cleared
+	ClipboardDeadline time.Time
 }
 
 func NewStatusBar(currentUser string) StatusBar {
@@ -40,6 +44,17 @@ func (bar *StatusBar) Update(msg tea.Msg) tea.Cmd {
 	case StatusBarMsg:
 		bar.Message = msgType.Message
 		bar.ErrorMessage = msgType.ErrorMessage
+
+	case tlockmessages.ClipboardCopiedMsg:
+		bar.ClipboardDeadline = msgType.Deadline
+
+	case tlockmessages.ClipboardClearedMsg:
+		bar.ClipboardDeadline = time.Time{}
+
+		if msgType.Cleared {
+			bar.Message = "Cleared the copied code from the clipboard"
+			bar.ErrorMessage = false
+		}
 	}
 
 	return nil
@@ -49,7 +64,7 @@ func (bar *StatusBar) View() string {
 	// Get width
 	width, _, _ := term.GetSize(int(os.Stdout.Fd()))
 
-	items := make([]string, 5)
+	items := make([]string, 6)
 
 	// Add app name
 	items[0] = tlockstyles.Styles.AccentBgItem.Render("TLOCK")
@@ -57,11 +72,16 @@ func (bar *StatusBar) View() string {
 	// Add version
 	items[1] = tlockstyles.Styles.OverlayItem.Render(constants.VERSION)
 
+	// Clipboard clear countdown
+	if remaining := time.Until(bar.ClipboardDeadline); remaining > 0 {
+		items[3] = tlockstyles.Styles.OverlayItem.Render(fmt.Sprintf("Clipboard clears in %ds", int(remaining.Round(time.Second).Seconds())))
+	}
+
 	// Current date, maybe?
-	items[3] = tlockstyles.Styles.OverlayItem.Render(time.Now().Format("2 January, 2006"))
+	items[4] = tlockstyles.Styles.OverlayItem.Render(time.Now().Format("2 January, 2006"))
 
 	// Current logged in user
-	items[4] = tlockstyles.Styles.AccentBgItem.Render(bar.CurrentUser)
+	items[5] = tlockstyles.Styles.AccentBgItem.Render(bar.CurrentUser)
 
 	// Sub width to fill in the gap
 	for _, item := range items {
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Names of the keys that are not a single character, as reported by bubbletea
+var KEY_NAMES = []string{
+	"ctrl+@", "ctrl+a", "ctrl+b", "ctrl+c", "ctrl+d", "ctrl+e", "ctrl+f", "ctrl+g", "ctrl+h", "ctrl+j",
+	"ctrl+k", "ctrl+l", "ctrl+n", "ctrl+o", "ctrl+p", "ctrl+q", "ctrl+r", "ctrl+s", "ctrl+t", "ctrl+u",
+	"ctrl+v", "ctrl+w", "ctrl+x", "ctrl+y", "ctrl+z", "ctrl+\\", "ctrl+]", "ctrl+^", "ctrl+_",
+	"tab", "shift+tab", "enter", "esc", "backspace", "delete", "insert", " ",
+	"up", "down", "left", "right",
+	"ctrl+up", "ctrl+down", "ctrl+left", "ctrl+right",
+	"shift+up", "shift+down", "shift+left", "shift+right",
+	"ctrl+shift+up", "ctrl+shift+down", "ctrl+shift+left", "ctrl+shift+right",
+	"home", "end", "ctrl+home", "ctrl+end", "shift+home", "shift+end", "ctrl+shift+home", "ctrl+shift+end",
+	"pgup", "pgdown", "ctrl+pgup", "ctrl+pgdown",
+	"f1", "f2", "f3", "f4", "f5", "f6", "f7", "f8", "f9", "f10",
+	"f11", "f12", "f13", "f14", "f15", "f16", "f17", "f18", "f19", "f20",
+}
+
+// Returns true if the key can be pressed
+// A key is either a single character or one of the key names, optionally prefixed with `alt+`
+func ValidKey(key string) bool {
+	key = strings.TrimPrefix(key, "alt+")
+
+	return utf8.RuneCountInString(key) == 1 || slices.Contains(KEY_NAMES, key)
+}
@@ -11,26 +11,55 @@ import (
 // Default theme
 var DEFAULT_THEME = "Catppuccin"
 
+// Layouts of the tokens view
+const (
+	// Cards with the time left bar
+	LayoutCards = "cards"
+
+	// One line per token
+	LayoutCompact = "compact"
+
+	// Cards in multiple columns
+	LayoutGrid = "grid"
+)
+
+// All the layouts, in the order they are switched
+var LAYOUTS = []string{LayoutCards, LayoutCompact, LayoutGrid}
+
 // TLock config is the config which is overriden by tlock itself
 type TLockConfig struct {
 	// Current theme
-	// Defaults to `Catppuccin`
+	// Defaults to `Catppuccin`, or to the global theme for the per-user config
 	CurrentTheme string `yaml:"current_theme"`
+
+	// Layout of the tokens view
+	// Defaults to `cards`, or to the global layout for the per-user config
+	Layout string `yaml:"layout"`
 }
 
 // Returns the default config
 func DefaultTLockConfig() TLockConfig {
 	return TLockConfig{
 		CurrentTheme: DEFAULT_THEME,
+		Layout:       LayoutCards,
 	}
 }
 
 // Loads the config from the file
 func GetTLockConfig() TLockConfig {
-	default_config := DefaultTLockConfig()
+	return readTLockConfig(paths.TLOCK_CONFIG, DefaultTLockConfig())
+}
 
+// Loads the config of the user from the file
+// Empty values mean that the global config should be used
+func GetUserTLockConfig(user string) TLockConfig {
+	return readTLockConfig(paths.UserTLockConfigFor(user), TLockConfig{})
+}
+
+// Reads the config from the given path on top of the defaults
+func readTLockConfig(path string, default_config TLockConfig) TLockConfig {
 	// Read raw
-	if config_raw, err := os.ReadFile(paths.TLOCK_CONFIG); err == nil {
+	if config_raw, err := os.ReadFile(path); err == nil {
 		binary.Unmarshal(config_raw, &default_config)
 	}
 
@@ -40,11 +69,21 @@ func GetTLockConfig() TLockConfig {
 
 // Writes the config
 func (config TLockConfig) Write() {
+	config.writeTo(paths.TLOCK_CONFIG)
+}
+
+// Writes the config for the user
+func (config TLockConfig) WriteUser(user string) {
+	config.writeTo(paths.UserTLockConfigFor(user))
+}
+
+// Writes the config to the given path
+func (config TLockConfig) writeTo(path string) {
 	// Marshal
 	data, _ := binary.Marshal(config)
 
 	// Create file
-	if file, err := utils.EnsureExists(paths.TLOCK_CONFIG); err == nil {
+	if file, err := utils.EnsureExists(path); err == nil {
 		file.Write(data)
 	}
 }
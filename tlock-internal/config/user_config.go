@@ -1,7 +1,13 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	_ "embed"
 
@@ -38,21 +44,207 @@ func (key *Keybinding) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// Glyphs shown in the help menu instead of the names of the arrow keys
+var KEY_GLYPHS = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+	" ":     "space",
+}
+
+// Returns the keys as shown in the help menu, like `↑/k`
+func (key Keybinding) HelpKeys() string {
+	keys := make([]string, 0)
+
+	for _, name := range key.Keys() {
+		if glyph, ok := KEY_GLYPHS[name]; ok {
+			name = glyph
+		}
+
+		keys = append(keys, name)
+	}
+
+	return strings.Join(keys, "/")
+}
+
+// Returns the key binding with the given description in the help menu
+func (key Keybinding) WithHelp(desc string) bubblekey.Binding {
+	return bubblekey.NewBinding(
+		bubblekey.WithKeys(key.Keys()...),
+		bubblekey.WithHelp(key.HelpKeys(), desc),
+	)
+}
+
+// Combines the keybindings into a single one, like the ones for switching inputs
+func Combine(desc string, keybindings ...Keybinding) bubblekey.Binding {
+	keys := make([]string, 0)
+	help := make([]string, 0)
+
+	for _, keybinding := range keybindings {
+		keys = append(keys, keybinding.Keys()...)
+		help = append(help, keybinding.HelpKeys())
+	}
+
+	return bubblekey.NewBinding(
+		bubblekey.WithKeys(keys...),
+		bubblekey.WithHelp(strings.Join(help, "/"), desc),
+	)
+}
+
 // Quick utility to create key binding
 func new_key(keys ...string) Keybinding {
 	return Keybinding{Binding: bubblekey.NewBinding(bubblekey.WithKeys(keys...))}
 }
 
+// Code visibility modes
+const (
+	// Only the code of the focused token is shown
+	VisibilityFocused = "focused"
+
+	// Every code is hidden until it is revealed
+	VisibilityHidden = "hidden"
+
+	// Every code is shown
+	VisibilityAll = "all"
+)
+
+// All the code visibility modes
+var VISIBILITIES = []string{VisibilityFocused, VisibilityHidden, VisibilityAll}
+
 // Keybindings config
 type UserConfiguration struct {
 	// Whether to enable icons
 	EnableIcons bool `yaml:"enable_icons"`
 
+	// Command which prints the vault password, like a password manager
+	PasswordCommand []string `yaml:"password_command"`
+
+	// Seconds after which a copied code is cleared from the clipboard, 0 to never clear it
+	ClipboardClearTimeout int `yaml:"clipboard_clear_timeout"`
+
+	// Clipboard backend: auto, system, osc52, tmux or command
+	ClipboardBackend string `yaml:"clipboard_backend"`
+
+	// Command which reads the code from its stdin, used by the command backend
+	ClipboardCommand []string `yaml:"clipboard_command"`
+
+	// Which codes are shown: focused, hidden or all
+	CodeVisibility string `yaml:"code_visibility"`
+
+	// Seconds for which a revealed code is shown
+	RevealTimeout int `yaml:"reveal_timeout"`
+
+	// Days after which the deleted tokens and folders are removed from the trash, 0 to keep them
+	TrashRetentionDays int `yaml:"trash_retention_days"`
+
+	// Global keybindings
+	Global GlobalKeyBinds `yaml:"global_keybindings"`
+
+	// Dashboard keybindings
+	Dashboard DashboardKeyBinds `yaml:"dashboard_keybindings"`
+
+	// Navigation keybindings
+	Navigation NavigationKeyBinds `yaml:"navigation_keybindings"`
+
 	// Folder keybindings
 	Folder FolderKeyBinds `yaml:"folders_keybindings"`
 
 	// Token keybindings
 	Tokens TokenKeyBinds `yaml:"tokens_keybindings"`
+
+	// Add from screen keybindings
+	FromScreen FromScreenKeyBinds `yaml:"from_screen_keybindings"`
+
+	// Trash screen keybindings
+	Trash TrashKeyBinds `yaml:"trash_keybindings"`
+
+	// Login screen keybindings
+	Auth AuthKeyBinds `yaml:"auth_keybindings"`
+}
+
+// Global keybinds, which work on every screen
+type GlobalKeyBinds struct {
+	// Quit tlock
+	Quit Keybinding `yaml:"quit"`
+}
+
+// Dashboard keybinds
+type DashboardKeyBinds struct {
+	// Help menu
+	Help Keybinding `yaml:"help"`
+
+	// Change theme
+	ChangeTheme Keybinding `yaml:"change_theme"`
+
+	// Open the trash
+	Trash Keybinding `yaml:"trash"`
+
+	// Undo the last change to the vault
+	Undo Keybinding `yaml:"undo"`
+
+	// Redo the last undone change
+	Redo Keybinding `yaml:"redo"`
+}
+
+// Navigation keybinds, used by the lists, forms and dialogs
+type NavigationKeyBinds struct {
+	// Move up in a list
+	Up Keybinding `yaml:"up"`
+
+	// Move down in a list
+	Down Keybinding `yaml:"down"`
+
+	// Go back to the previous screen
+	GoBack Keybinding `yaml:"go_back"`
+
+	// Confirm, like submitting a form or choosing an item
+	Confirm Keybinding `yaml:"confirm"`
+
+	// Focus the next input of a form
+	NextInput Keybinding `yaml:"next_input"`
+
+	// Focus the previous input of a form
+	PreviousInput Keybinding `yaml:"previous_input"`
+
+	// Choose the option on the left
+	OptionLeft Keybinding `yaml:"option_left"`
+
+	// Choose the option on the right
+	OptionRight Keybinding `yaml:"option_right"`
+
+	// Browse the icons in the token form
+	BrowseIcons Keybinding `yaml:"browse_icons"`
+}
+
+// Add from screen keybinds
+type FromScreenKeyBinds struct {
+	// Take the screenshot again
+	Retake Keybinding `yaml:"retake"`
+}
+
+// Trash screen keybinds
+type TrashKeyBinds struct {
+	// Restore the focused item to another folder
+	RestoreTo Keybinding `yaml:"restore_to"`
+
+	// Permanently delete the focused item
+	Purge Keybinding `yaml:"purge"`
+
+	// Permanently delete every item
+	Empty Keybinding `yaml:"empty"`
+}
+
+// Login screen keybinds
+type AuthKeyBinds struct {
+	// Create a new user
+	NewUser Keybinding `yaml:"new_user"`
+
+	// Open the options of the focused user
+	UserOptions Keybinding `yaml:"user_options"`
+
+	// Choose the global theme, which is used by the users without their own
+	ChangeTheme Keybinding `yaml:"change_theme"`
 }
 
 // Folder keybinds
@@ -60,6 +252,21 @@ type FolderKeyBinds struct {
 	// Add folder
 	Add Keybinding `yaml:"add"`
 
+	// Add smart folder
+	AddSmart Keybinding `yaml:"add_smart"`
+
+	// Add folder inside of the focused one
+	AddSubfolder Keybinding `yaml:"add_subfolder"`
+
+	// Move the folder inside of another one
+	MoveInto Keybinding `yaml:"move_into"`
+
+	// Show the subfolders
+	Expand Keybinding `yaml:"expand"`
+
+	// Hide the subfolders
+	Collapse Keybinding `yaml:"collapse"`
+
 	// Edit folder
 	Edit Keybinding `yaml:"edit"`
 
@@ -113,67 +320,249 @@ type TokenKeyBinds struct {
 
 	// Next token for HOTP
 	Move Keybinding `yaml:"move"`
+
+	// Reveal the code of the focused token
+	Reveal Keybinding `yaml:"reveal"`
+
+	// Toggle showing the codes of all the tokens
+	ShowAll Keybinding `yaml:"toggle_show_all"`
+
+	// Switch to the next layout
+	SwitchLayout Keybinding `yaml:"switch_layout"`
+
+	// Switch to the next sort mode of the folder
+	Sort Keybinding `yaml:"sort"`
+
+	// Add or remove the focused token from the favorites
+	Favorite Keybinding `yaml:"toggle_favorite"`
+
+	// Select or unselect the focused token for a bulk action
+	Select Keybinding `yaml:"toggle_select"`
+
+	// Select every token in the folder, or unselect them
+	SelectAll Keybinding `yaml:"select_all"`
+
+	// Add tags to the selected tokens
+	Tag Keybinding `yaml:"tag"`
+
+	// Export the selected tokens as otpauth URIs
+	Export Keybinding `yaml:"export"`
+
+	// Show the details of the focused token
+	Details Keybinding `yaml:"details"`
 }
 
 // Returns the default keybindings
 func DefaultUserConfiguration() UserConfiguration {
 	return UserConfiguration{
-		EnableIcons: false,
-		Folder:      DefaultFolderKeyBinds(),
-		Tokens:      DefaultTokensKeyBinds(),
+		EnableIcons:           false,
+		ClipboardClearTimeout: 30,
+		ClipboardBackend:      "auto",
+		CodeVisibility:        VisibilityFocused,
+		RevealTimeout:         10,
+		TrashRetentionDays:    30,
+		Global:                DefaultGlobalKeyBinds(),
+		Dashboard:             DefaultDashboardKeyBinds(),
+		Navigation:            DefaultNavigationKeyBinds(),
+		Folder:                DefaultFolderKeyBinds(),
+		Tokens:                DefaultTokensKeyBinds(),
+		FromScreen:            DefaultFromScreenKeyBinds(),
+		Trash:                 DefaultTrashKeyBinds(),
+		Auth:                  DefaultAuthKeyBinds(),
+	}
+}
+
+// Default global keybindings
+func DefaultGlobalKeyBinds() GlobalKeyBinds {
+	return GlobalKeyBinds{
+		Quit: new_key("ctrl+c", "ctrl+q"),
+	}
+}
+
+// Default dashboard keybindings
+func DefaultDashboardKeyBinds() DashboardKeyBinds {
+	return DashboardKeyBinds{
+		Help:        new_key("?"),
+		ChangeTheme: new_key("ctrl+t"),
+		Trash:       new_key("T"),
+		Undo:        new_key("u"),
+		Redo:        new_key("ctrl+r"),
+	}
+}
+
+// Default navigation keybindings
+func DefaultNavigationKeyBinds() NavigationKeyBinds {
+	return NavigationKeyBinds{
+		Up:            new_key("up", "k"),
+		Down:          new_key("down", "j"),
+		GoBack:        new_key("esc"),
+		Confirm:       new_key("enter"),
+		NextInput:     new_key("tab"),
+		PreviousInput: new_key("shift+tab"),
+		OptionLeft:    new_key("left"),
+		OptionRight:   new_key("right"),
+		BrowseIcons:   new_key("ctrl+o"),
 	}
 }
 
 // Default folder keybindings
 func DefaultFolderKeyBinds() FolderKeyBinds {
 	return FolderKeyBinds{
-		Add:      new_key("A"),
-		Edit:     new_key("E"),
-		Next:     new_key("tab"),
-		Previous: new_key("shift+tab"),
-		MoveUp:   new_key("ctrl+up"),
-		MoveDown: new_key("ctrl+down"),
-		Delete:   new_key("D"),
+		Add:          new_key("A"),
+		AddSmart:     new_key("Q"),
+		AddSubfolder: new_key("N"),
+		MoveInto:     new_key("M"),
+		Expand:       new_key("l", "right"),
+		Collapse:     new_key("h", "left"),
+		Edit:         new_key("E"),
+		Next:         new_key("tab"),
+		Previous:     new_key("shift+tab"),
+		MoveUp:       new_key("ctrl+up"),
+		MoveDown:     new_key("ctrl+down"),
+		Delete:       new_key("D"),
 	}
 }
 
 // Default tokens keybindings
 func DefaultTokensKeyBinds() TokenKeyBinds {
 	return TokenKeyBinds{
-		Add:       new_key("a"),
-		Edit:      new_key("e"),
-		Next:      new_key("j"),
-		Previous:  new_key("k"),
-		MoveUp:    new_key("J"),
-		MoveDown:  new_key("K"),
-		Delete:    new_key("d"),
-		AddScreen: new_key("s"),
-		Copy:      new_key("c"),
-		Move:      new_key("m"),
-		NextHOTP:  new_key("n"),
+		Add:          new_key("a"),
+		Edit:         new_key("e"),
+		Next:         new_key("j", "down"),
+		Previous:     new_key("k", "up"),
+		MoveUp:       new_key("J"),
+		MoveDown:     new_key("K"),
+		Delete:       new_key("d"),
+		AddScreen:    new_key("s"),
+		Copy:         new_key("c"),
+		Move:         new_key("m"),
+		NextHOTP:     new_key("n"),
+		Reveal:       new_key("v"),
+		ShowAll:      new_key("V"),
+		SwitchLayout: new_key("L"),
+		Sort:         new_key("S"),
+		Favorite:     new_key("f"),
+		Select:       new_key(" "),
+		SelectAll:    new_key("ctrl+a"),
+		Tag:          new_key("t"),
+		Export:       new_key("x"),
+		Details:      new_key("i"),
 	}
 }
 
+// Default add from screen keybindings
+func DefaultFromScreenKeyBinds() FromScreenKeyBinds {
+	return FromScreenKeyBinds{
+		Retake: new_key("r"),
+	}
+}
+
+// Default trash keybindings
+func DefaultTrashKeyBinds() TrashKeyBinds {
+	return TrashKeyBinds{
+		RestoreTo: new_key("m"),
+		Purge:     new_key("d"),
+		Empty:     new_key("D"),
+	}
+}
+
+// Default login screen keybindings
+func DefaultAuthKeyBinds() AuthKeyBinds {
+	return AuthKeyBinds{
+		NewUser:     new_key("c"),
+		UserOptions: new_key("o"),
+		ChangeTheme: new_key("ctrl+t"),
+	}
+}
+
+// Returns the clipboard clear timeout as a duration
+func (config UserConfiguration) ClipboardTimeout() time.Duration {
+	return time.Duration(config.ClipboardClearTimeout) * time.Second
+}
+
+// Returns the time after which the deleted tokens and folders are removed from the trash, 0 to keep them
+func (config UserConfiguration) TrashRetention() time.Duration {
+	return time.Duration(config.TrashRetentionDays) * 24 * time.Hour
+}
+
+// Returns the time for which a revealed code is shown
+func (config UserConfiguration) RevealDuration() time.Duration {
+	return time.Duration(config.RevealTimeout) * time.Second
+}
+
 // Load configuration for a specific user
-func LoadUserConfig(user string) UserConfiguration {
+// The invalid values are replaced with the default ones, and returned along with the errors
+func LoadUserConfig(user string) (UserConfiguration, error) {
+	return loadConfig(paths.UserConfigFor(user), "config.yaml")
+}
+
+// Load the global configuration, which is used before logging in
+// The invalid values are replaced with the default ones, and returned along with the errors
+func LoadGlobalConfig() (UserConfiguration, error) {
+	return loadConfig(paths.GLOBAL_CONFIG, "global config.yaml")
+}
+
+// Loads the configuration from the given path, writing the default one if it does not exist
+// The errors are prefixed with the given name
+func loadConfig(path, name string) (UserConfiguration, error) {
 	// Default key bindings
 	default_config := DefaultUserConfiguration()
 
-	// Parse the file if it is read
-	if raw, err := os.ReadFile(paths.UserConfigFor(user)); err == nil {
-		yaml.Unmarshal(raw, &default_config)
-	} else {
-		WriteDefault(user)
+	// Read the file, or write the default one if it does not exist
+	raw, err := os.ReadFile(path)
+
+	if err != nil {
+		writeDefaultTo(path)
+
+		return default_config, nil
+	}
+
+	// Parse
+	config := DefaultUserConfiguration()
+
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+
+	errs := make([]error, 0)
+
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		// Values of the wrong type or unknown fields leave the rest of the file parsed
+		var typeErr *yaml.TypeError
+
+		if !errors.As(err, &typeErr) {
+			return default_config, fmt.Errorf("%s: %w", name, err)
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+	}
+
+	// Validate, where the invalid values are replaced with the default ones
+	if err := config.repair(); err != nil {
+		errs = append(errs, utils.PrefixErrors(name, err))
 	}
 
 	// Return
-	return default_config
+	return config, errors.Join(errs...)
+}
+
+// Returns the last modification time of the user's config, zero if it does not exist
+func UserConfigModTime(user string) time.Time {
+	if info, err := os.Stat(paths.UserConfigFor(user)); err == nil {
+		return info.ModTime()
+	}
+
+	return time.Time{}
 }
 
 // Writes the default keybindings configuration
 func WriteDefault(user string) {
+	writeDefaultTo(paths.UserConfigFor(user))
+}
+
+// Writes the default configuration to the given path
+func writeDefaultTo(path string) {
 	// Open file
-	if file, err := utils.EnsureExists(paths.UserConfigFor(user)); err == nil {
+	if file, err := utils.EnsureExists(path); err == nil {
 		file.Write(DEFAULT_CONFIG_RAW)
 	}
 }
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/eklairs/tlock/tlock-internal/clipboard"
+)
+
+// Error representing that the clipboard clear timeout is negative
+var ERR_NEGATIVE_TIMEOUT = errors.New("clipboard_clear_timeout cannot be negative")
+
+// Error representing that the password command has no program
+var ERR_EMPTY_PASSWORD_COMMAND = errors.New("password_command must start with the program to run")
+
+// Error representing that the code visibility is not one of the modes
+var ERR_UNKNOWN_VISIBILITY = errors.New("Unknown code_visibility, must be one of focused, hidden or all")
+
+// Error representing that the reveal timeout is not positive
+var ERR_REVEAL_TIMEOUT = errors.New("reveal_timeout must be greater than 0")
+
+// Error representing that the trash retention is negative
+var ERR_NEGATIVE_RETENTION = errors.New("trash_retention_days cannot be negative")
+
+// Sections whose keybindings are active at the same time, so they cannot share a key
+var BINDING_SCOPES = [][]string{
+	{"global_keybindings", "dashboard_keybindings", "folders_keybindings", "tokens_keybindings"},
+	{"global_keybindings", "navigation_keybindings", "from_screen_keybindings", "auth_keybindings"},
+	{"global_keybindings", "navigation_keybindings", "trash_keybindings"},
+}
+
+// Keybinding along with its name in the config, like `tokens_keybindings.add`
+type namedBinding struct {
+	// Section in the config
+	section string
+
+	// Name in the config
+	name string
+
+	// Keybinding, which can be replaced when the config is addressable
+	binding reflect.Value
+}
+
+// Returns the keybinding
+func (binding namedBinding) keybinding() Keybinding {
+	return binding.binding.Interface().(Keybinding)
+}
+
+// Validates the values of the configuration
+func (config UserConfiguration) Validate() error {
+	return config.repair()
+}
+
+// Validates the values of the configuration, and replaces the invalid ones with the default values
+// Keys which are not valid or conflict with another action are removed from the keybinding, so the rest of the config stays usable
+func (config *UserConfiguration) repair() error {
+	errs := make([]error, 0)
+	defaults := DefaultUserConfiguration()
+
+	// Clipboard
+	if config.ClipboardClearTimeout < 0 {
+		errs = append(errs, ERR_NEGATIVE_TIMEOUT)
+		config.ClipboardClearTimeout = defaults.ClipboardClearTimeout
+	}
+
+	if !slices.Contains(clipboard.BACKENDS, config.ClipboardBackend) {
+		errs = append(errs, clipboard.ERR_UNKNOWN_BACKEND)
+		config.ClipboardBackend = defaults.ClipboardBackend
+	}
+
+	if config.ClipboardBackend == clipboard.BackendCommand && (len(config.ClipboardCommand) == 0 || config.ClipboardCommand[0] == "") {
+		errs = append(errs, clipboard.ERR_NO_COMMAND)
+		config.ClipboardBackend = defaults.ClipboardBackend
+	}
+
+	// Code visibility
+	if !slices.Contains(VISIBILITIES, config.CodeVisibility) {
+		errs = append(errs, ERR_UNKNOWN_VISIBILITY)
+		config.CodeVisibility = defaults.CodeVisibility
+	}
+
+	if config.RevealTimeout <= 0 {
+		errs = append(errs, ERR_REVEAL_TIMEOUT)
+		config.RevealTimeout = defaults.RevealTimeout
+	}
+
+	// Trash
+	if config.TrashRetentionDays < 0 {
+		errs = append(errs, ERR_NEGATIVE_RETENTION)
+		config.TrashRetentionDays = defaults.TrashRetentionDays
+	}
+
+	// Password command
+	if len(config.PasswordCommand) != 0 && config.PasswordCommand[0] == "" {
+		errs = append(errs, ERR_EMPTY_PASSWORD_COMMAND)
+		config.PasswordCommand = defaults.PasswordCommand
+	}
+
+	// Keybindings
+	errs = append(errs, repairBindings(config.bindings(), defaults.bindings())...)
+
+	return errors.Join(errs...)
+}
+
+// Validates the key names and reports the keys bound to more than one action of the same scope
+// Such keys are removed from the keybindings, where a conflicting key is kept on the action the user bound it to
+func repairBindings(bindings, defaults []namedBinding) []error {
+	errs := make([]error, 0)
+
+	// Impossible keys
+	for _, binding := range bindings {
+		for _, key := range binding.keybinding().Keys() {
+			if !ValidKey(key) {
+				errs = append(errs, fmt.Errorf("%s: %q is not a valid key", binding.name, key))
+				removeKey(binding, key)
+			}
+		}
+	}
+
+	// Whether the keybinding is still the default one, the bindings are in the same order as the defaults
+	isDefault := func(index int) bool {
+		return slices.Equal(bindings[index].keybinding().Keys(), defaults[index].keybinding().Keys())
+	}
+
+	// Conflicts, where the ones between sections shared by the scopes are only reported once
+	reported := make(map[string]bool)
+
+	for _, scope := range BINDING_SCOPES {
+		// Action each key is bound to
+		boundTo := make(map[string]int)
+
+		for index, binding := range bindings {
+			if !slices.Contains(scope, binding.section) {
+				continue
+			}
+
+			for _, key := range binding.keybinding().Keys() {
+				other, ok := boundTo[key]
+
+				if !ok {
+					boundTo[key] = index
+					continue
+				}
+
+				// Conflict
+				err := fmt.Errorf("%s: %q is already bound to %s", binding.name, key, bindings[other].name)
+
+				if !reported[err.Error()] {
+					errs = append(errs, err)
+					reported[err.Error()] = true
+				}
+
+				// Keep the key which the user chose over a default one, like a key of a newly added action
+				if isDefault(other) && !isDefault(index) {
+					removeKey(bindings[other], key)
+					boundTo[key] = index
+				} else {
+					removeKey(binding, key)
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// Removes the key from the keybinding
+func removeKey(binding namedBinding, key string) {
+	keys := slices.DeleteFunc(slices.Clone(binding.keybinding().Keys()), func(other string) bool { return other == key })
+
+	binding.binding.Set(reflect.ValueOf(new_key(keys...)))
+}
+
+// Returns all the keybindings in the config, in the order they are declared
+func (config *UserConfiguration) bindings() []namedBinding {
+	bindings := make([]namedBinding, 0)
+
+	// Go through the keybinding sections
+	value := reflect.ValueOf(config).Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		section := value.Field(i)
+
+		if section.Kind() != reflect.Struct {
+			continue
+		}
+
+		sectionName := value.Type().Field(i).Tag.Get("yaml")
+
+		for j := 0; j < section.NumField(); j++ {
+			if _, ok := section.Field(j).Interface().(Keybinding); ok {
+				bindings = append(bindings, namedBinding{
+					section: sectionName,
+					name:    fmt.Sprintf("%s.%s", sectionName, section.Type().Field(j).Tag.Get("yaml")),
+					binding: section.Field(j),
+				})
+			}
+		}
+	}
+
+	return bindings
+}
@@ -3,11 +3,13 @@ package context
 import (
 	"encoding/json"
 	"slices"
-	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	tlockcore "github.com/eklairs/tlock/tlock-core"
 	"github.com/eklairs/tlock/tlock-internal/config"
+	"github.com/eklairs/tlock/tlock-internal/password"
+	"github.com/eklairs/tlock/tlock-internal/paths"
 	tlockvendor "github.com/eklairs/tlock/tlock-vendor"
 )
 
@@ -19,44 +21,65 @@ type Icon struct {
 // Represents a theme
 type Theme struct {
 	// Name
-	Name string
+	Name string `json:"name" yaml:"name"`
 
 	// Background
-	Background lipgloss.Color
+	Background lipgloss.Color `json:"background" yaml:"background"`
 
 	// Background over
-	BackgroundOver lipgloss.Color
+	BackgroundOver lipgloss.Color `json:"backgroundOver" yaml:"backgroundOver"`
 
 	// Sub text
-	SubText lipgloss.Color
+	SubText lipgloss.Color `json:"subText" yaml:"subText"`
 
 	// Accent
-	Accent lipgloss.Color
+	Accent lipgloss.Color `json:"accent" yaml:"accent"`
 
 	// Foreground
-	Foreground lipgloss.Color
+	Foreground lipgloss.Color `json:"foreground" yaml:"foreground"`
 
 	// Error
-	Error lipgloss.Color
+	Error lipgloss.Color `json:"error" yaml:"error"`
+
+	// Whether the theme was loaded from the themes directory
+	Custom bool `json:"-" yaml:"-"`
 }
 
 // Represents a context
 type Context struct {
 	// All the themes available
-	// Fetched from vendor
+	// Fetched from vendor, followed by the custom themes
 	Themes []Theme
 
+	// Errors from loading the custom themes
+	ThemeErrors []error
+
 	// Icons!
-	Icons map[string]Icon
+	Icons IconSet
 
 	// Config
 	TLockConfig config.TLockConfig
 
+	// Config of the logged in user, which overrides the global one
+	UserTLockConfig config.TLockConfig
+
+	// Logged in user, empty before login
+	CurrentUser string
+
 	// Core
 	Core *tlockcore.TLockCore
 
-	// User configuration
+	// User configuration, which is the global one before login
 	Config config.UserConfiguration
+
+	// Last modification time of the config of the logged in user, used to reload it when it changes
+	ConfigModTime time.Time
+
+	// Error from loading the global configuration
+	ConfigError error
+
+	// Non-interactive password sources
+	Password *password.Sources
 }
 
 // Initializes a new instance of the context
@@ -66,9 +89,14 @@ func InitializeContext() Context {
 	var themes []Theme
 	json.Unmarshal(tlockvendor.ThemesJSON, &themes)
 
+	// Add custom themes
+	custom, themeErrors := LoadCustomThemes(paths.THEMES_DIR, themes)
+	themes = append(themes, custom...)
+
 	// Parse icons
 	var icons struct {
-		Icons map[string]Icon
+		Icons   map[string]Icon
+		Aliases map[string]string
 	}
 
 	json.Unmarshal(tlockvendor.IconsJSON, &icons)
@@ -76,25 +104,31 @@ func InitializeContext() Context {
 	// Initialize core
 	core, _ := tlockcore.New()
 
+	// Global configuration
+	globalConfig, configErr := config.LoadGlobalConfig()
+
 	// Return
 	return Context{
 		Themes:      themes,
-		Icons:       icons.Icons,
+		ThemeErrors: themeErrors,
+		Icons:       NewIconSet(icons.Icons, icons.Aliases),
 		Core:        core,
-		Config:      config.DefaultUserConfiguration(),
+		Config:      globalConfig,
+		ConfigError: configErr,
 		TLockConfig: config.GetTLockConfig(),
+		Password:    password.New(),
 	}
 }
 
 // Finds the index of the theme
 func (context Context) findTheme(name string) int {
-	return slices.IndexFunc(context.Themes, func(theme Theme) bool { return strings.ToLower(theme.Name) == strings.ToLower(name) })
+	return findThemeIn(context.Themes, name)
 }
 
-// Returns the current theme spec
-func (context Context) GetCurrentTheme() Theme {
+// Returns the theme with the given name, or the default theme if it is not found
+func (context Context) themeNamed(name string) Theme {
 	// Get theme index
-	theme_index := context.findTheme(context.TLockConfig.CurrentTheme)
+	theme_index := context.findTheme(name)
 
 	// If not found, then use the default theme
 	if theme_index == -1 {
@@ -105,11 +139,86 @@ func (context Context) GetCurrentTheme() Theme {
 	return context.Themes[theme_index]
 }
 
+// Returns the current theme spec
+// The theme of the logged in user is used if it has one, otherwise the global theme
+func (context Context) GetCurrentTheme() Theme {
+	if context.UserTLockConfig.CurrentTheme != "" {
+		return context.themeNamed(context.UserTLockConfig.CurrentTheme)
+	}
+
+	return context.themeNamed(context.TLockConfig.CurrentTheme)
+}
+
+// Returns the theme of the given user, falling back to the global theme
+func (context Context) ThemeFor(user string) Theme {
+	if theme := config.GetUserTLockConfig(user).CurrentTheme; theme != "" {
+		return context.themeNamed(theme)
+	}
+
+	return context.themeNamed(context.TLockConfig.CurrentTheme)
+}
+
+// Returns the layout of the tokens view
+// The layout of the logged in user is used if it has one, otherwise the global layout
+func (context Context) CurrentLayout() string {
+	layout := context.UserTLockConfig.Layout
+
+	if layout == "" {
+		layout = context.TLockConfig.Layout
+	}
+
+	// Unknown layouts are replaced by the default one
+	if !slices.Contains(config.LAYOUTS, layout) {
+		return config.LayoutCards
+	}
+
+	return layout
+}
+
+// Sets the logged in user and loads its config
+func (context *Context) SetUser(user string) {
+	context.CurrentUser = user
+	context.UserTLockConfig = config.GetUserTLockConfig(user)
+}
+
 // Sets the theme
+// It is saved for the logged in user, or globally if no one is logged in
 func (context *Context) SetTheme(theme string) {
+	// Before login
+	if context.CurrentUser == "" {
+		// Update current theme
+		context.TLockConfig.CurrentTheme = theme
+
+		// Write
+		context.TLockConfig.Write()
+
+		return
+	}
+
 	// Update current theme
-	context.TLockConfig.CurrentTheme = theme
+	context.UserTLockConfig.CurrentTheme = theme
+
+	// Write
+	context.UserTLockConfig.WriteUser(context.CurrentUser)
+}
+
+// Sets the layout of the tokens view
+// It is saved for the logged in user, or globally if no one is logged in
+func (context *Context) SetLayout(layout string) {
+	// Before login
+	if context.CurrentUser == "" {
+		// Update layout
+		context.TLockConfig.Layout = layout
+
+		// Write
+		context.TLockConfig.Write()
+
+		return
+	}
+
+	// Update layout
+	context.UserTLockConfig.Layout = layout
 
 	// Write
-	context.TLockConfig.Write()
+	context.UserTLockConfig.WriteUser(context.CurrentUser)
 }
@@ -0,0 +1,142 @@
+package context
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Matches #rgb and #rrggbb colors
+var hexColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Loads the custom themes from the json and yaml files in the given directory
+// Themes which fail to load are skipped and their errors are returned
+func LoadCustomThemes(dir string, existing []Theme) ([]Theme, []error) {
+	themes := make([]Theme, 0)
+	errs := make([]error, 0)
+
+	// Read directory, it is fine if it does not exist
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("themes: %w", err))
+		}
+
+		return themes, errs
+	}
+
+	for _, entry := range entries {
+		extension := strings.ToLower(filepath.Ext(entry.Name()))
+
+		if entry.IsDir() || (extension != ".json" && extension != ".yaml" && extension != ".yml") {
+			continue
+		}
+
+		theme, err := loadThemeFile(filepath.Join(dir, entry.Name()))
+
+		// Names must be unique
+		if err == nil && (findThemeIn(existing, theme.Name) != -1 || findThemeIn(themes, theme.Name) != -1) {
+			err = fmt.Errorf("theme named %q already exists", theme.Name)
+		}
+
+		if err != nil {
+			errs = append(errs, utils.PrefixErrors(entry.Name(), err))
+			continue
+		}
+
+		themes = append(themes, theme)
+	}
+
+	return themes, errs
+}
+
+// Loads and validates a single theme file
+func loadThemeFile(file string) (Theme, error) {
+	var theme Theme
+
+	// Read
+	raw, err := os.ReadFile(file)
+
+	if err != nil {
+		return theme, err
+	}
+
+	// Parse
+	if strings.ToLower(filepath.Ext(file)) == ".json" {
+		err = json.Unmarshal(raw, &theme)
+	} else {
+		err = yaml.Unmarshal(raw, &theme)
+	}
+
+	if err != nil {
+		return theme, err
+	}
+
+	// Use the file name if the theme is not named
+	if strings.TrimSpace(theme.Name) == "" {
+		theme.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	theme.Custom = true
+
+	return theme, theme.Validate()
+}
+
+// Validates the colors of the theme
+// Colors must be hex colors like `#1e1e2e`, or ANSI colors from 0 to 255
+// The background must be a hex color as it is set on the terminal itself
+func (theme Theme) Validate() error {
+	errs := make([]error, 0)
+
+	// Background
+	if !hexColorRegex.MatchString(string(theme.Background)) {
+		errs = append(errs, fmt.Errorf("background: %q is not a hex color like #1e1e2e", theme.Background))
+	}
+
+	// Rest of the colors
+	colors := []struct {
+		name  string
+		color lipgloss.Color
+	}{
+		{"backgroundOver", theme.BackgroundOver},
+		{"subText", theme.SubText},
+		{"accent", theme.Accent},
+		{"foreground", theme.Foreground},
+		{"error", theme.Error},
+	}
+
+	for _, color := range colors {
+		if !validColor(string(color.color)) {
+			errs = append(errs, fmt.Errorf("%s: %q is not a hex color like #1e1e2e or an ANSI color from 0 to 255", color.name, color.color))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Returns true if the color is a hex color or an ANSI color
+func validColor(color string) bool {
+	if hexColorRegex.MatchString(color) {
+		return true
+	}
+
+	ansi, err := strconv.Atoi(color)
+
+	return err == nil && ansi >= 0 && ansi <= 255
+}
+
+// Finds the index of the theme with the given name
+func findThemeIn(themes []Theme, name string) int {
+	return slices.IndexFunc(themes, func(theme Theme) bool { return strings.EqualFold(theme.Name, name) })
+}
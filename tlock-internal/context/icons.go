@@ -0,0 +1,216 @@
+package context
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Words which are left out of the issuer when finding its icon, like `Inc.` in `GitHub Inc.`
+var ICON_STOP_WORDS = []string{"inc", "llc", "ltd", "corp", "corporation", "co", "company", "gmbh", "www", "accounts", "com", "net", "org", "io"}
+
+// Icons along with the index to find them by the issuer
+type IconSet struct {
+	// Icons by their name
+	Icons map[string]Icon
+
+	// Other names of the issuers, mapped to the name of their icon
+	Aliases map[string]string
+
+	// Normalized names and aliases, mapped to the name of their icon
+	index map[string]string
+
+	// Normalized keys of the index, sorted to keep the fuzzy matches stable
+	keys []string
+
+	// Icons which were already found for the issuers
+	matches map[string]string
+}
+
+// Initializes the icon set and its index
+func NewIconSet(icons map[string]Icon, aliases map[string]string) IconSet {
+	set := IconSet{
+		Icons:   icons,
+		Aliases: aliases,
+		index:   make(map[string]string),
+		matches: make(map[string]string),
+	}
+
+	for name := range icons {
+		set.index[normalizeIconName(name)] = name
+	}
+
+	// Aliases do not override the names of the icons
+	for alias, name := range aliases {
+		if _, ok := icons[name]; !ok {
+			continue
+		}
+
+		if _, ok := set.index[normalizeIconName(alias)]; !ok {
+			set.index[normalizeIconName(alias)] = name
+		}
+	}
+
+	for key := range set.index {
+		set.keys = append(set.keys, key)
+	}
+
+	slices.Sort(set.keys)
+
+	return set
+}
+
+// Returns the lowercased name with only the letters and digits
+func normalizeIconName(name string) string {
+	return strings.Join(iconWords(name, false), "")
+}
+
+// Splits the name into lowercased words, optionally without the stop words
+func iconWords(name string, skipStopWords bool) []string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if skipStopWords {
+		words = slices.DeleteFunc(words, func(word string) bool { return slices.Contains(ICON_STOP_WORDS, word) })
+	}
+
+	return words
+}
+
+// Returns the number of edits to turn one string into another
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// Returns the name of the icon for the issuer
+// It tries the whole issuer, then each of its words, and then the closest name to allow for typos
+func (set IconSet) Find(issuer string) (string, bool) {
+	// Exact name
+	if _, ok := set.Icons[issuer]; ok {
+		return issuer, true
+	}
+
+	// Already found
+	if name, ok := set.matches[issuer]; ok {
+		return name, name != ""
+	}
+
+	name := set.find(issuer)
+
+	if set.matches != nil {
+		set.matches[issuer] = name
+	}
+
+	return name, name != ""
+}
+
+// Finds the icon for the issuer, empty if there is none
+func (set IconSet) find(issuer string) string {
+	words := iconWords(issuer, true)
+
+	if len(words) == 0 {
+		return ""
+	}
+
+	// Whole issuer, like `AWS` or `Amazon Web Services`
+	if name, ok := set.index[normalizeIconName(issuer)]; ok {
+		return name
+	}
+
+	// Without the stop words, like `GitHub Inc.`
+	// The short ones are skipped, as there are icons like `C` or `Go`
+	key := strings.Join(words, "")
+
+	if name, ok := set.index[key]; ok && len(key) >= 3 {
+		return name
+	}
+
+	// Any of its words, like `accounts.google.com`
+	for _, word := range words {
+		if len(word) < 3 {
+			continue
+		}
+
+		if name, ok := set.index[word]; ok {
+			return name
+		}
+	}
+
+	// Closest name, only for the longer issuers as the short ones match too much
+	if len(key) < 5 {
+		return ""
+	}
+
+	best, bestDistance := "", len(key)/5+1
+
+	for _, candidate := range set.keys {
+		if distance := levenshtein(key, candidate); distance < bestDistance {
+			best, bestDistance = set.index[candidate], distance
+		}
+	}
+
+	return best
+}
+
+// Returns the icon for the issuer
+// The override is the name of the icon chosen for the token, which is used if it exists
+func (set IconSet) For(issuer, override string) (Icon, bool) {
+	if icon, ok := set.Icons[override]; ok {
+		return icon, true
+	}
+
+	if name, ok := set.Find(issuer); ok {
+		return set.Icons[name], true
+	}
+
+	return Icon{}, false
+}
+
+// Returns the names of the icons matching the search, sorted by their name
+func (set IconSet) Search(search string) []string {
+	search = normalizeIconName(search)
+	names := make([]string, 0)
+
+	for name := range set.Icons {
+		if strings.Contains(normalizeIconName(name), search) {
+			names = append(names, name)
+		}
+	}
+
+	// Aliases of the icons
+	for alias, name := range set.Aliases {
+		if strings.Contains(normalizeIconName(alias), search) && !slices.Contains(names, name) {
+			if _, ok := set.Icons[name]; ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	slices.SortFunc(names, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+
+	return names
+}
@@ -4,6 +4,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
@@ -32,10 +33,31 @@ type FormItemWrapped struct {
 	Validators []Validator
 }
 
+// Keys used by the form
+type KeyMap struct {
+	// Focus the next item
+	Next key.Binding
+
+	// Focus the previous item
+	Previous key.Binding
+
+	// Submit the form
+	Submit key.Binding
+
+	// Choose the option on the left in an option box
+	Left key.Binding
+
+	// Choose the option on the right in an option box
+	Right key.Binding
+}
+
 type Form struct {
 	// Items
 	Items []FormItemWrapped
 
+	// Keys
+	Keys KeyMap
+
 	// Focused index
 	FocusedIndex int
 
@@ -44,8 +66,8 @@ type Form struct {
 }
 
 // Initializes a new form item
-func New() Form {
-	return Form{}
+func New(keys KeyMap) Form {
+	return Form{Keys: keys}
 }
 
 // Adds a new input to the form
@@ -70,6 +92,8 @@ func (form *Form) AddOption(id, title, desc string, options []string) {
 			Title:       title,
 			Description: desc,
 			Values:      options,
+			KeyLeft:     form.Keys.Left,
+			KeyRight:    form.Keys.Right,
 		},
 		Enabled:    true,
 		Validators: []Validator{},
@@ -93,8 +117,8 @@ func (form *Form) Update(msg tea.Msg, vault *tlockvault.Vault) tea.Cmd {
 	switch msgType := msg.(type) {
 	case tea.KeyMsg:
 	match_key:
-		switch msgType.String() {
-		case "tab":
+		switch {
+		case key.Matches(msgType, form.Keys.Next):
 			if form.FocusedIndex != len(form.Items)-1 {
 				next := form.FocusedIndex + 1
 
@@ -106,7 +130,7 @@ func (form *Form) Update(msg tea.Msg, vault *tlockvault.Vault) tea.Cmd {
 				// Change focus
 				form.switchFocus(form.FocusedIndex, next)
 			}
-		case "shift+tab":
+		case key.Matches(msgType, form.Keys.Previous):
 			if form.FocusedIndex != 0 {
 				next := form.FocusedIndex - 1
 
@@ -118,7 +142,7 @@ func (form *Form) Update(msg tea.Msg, vault *tlockvault.Vault) tea.Cmd {
 				// Change focus
 				form.switchFocus(form.FocusedIndex, next)
 			}
-		case "enter":
+		case key.Matches(msgType, form.Keys.Submit):
 			data := make(map[string]string)
 
 			// Validate them all!
@@ -162,6 +186,33 @@ func (form *Form) Update(msg tea.Msg, vault *tlockvault.Vault) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// Returns the values of the items, with the default values for the empty ones
+// The error is the first one from the validators, which is not shown on the items
+func (form Form) Validate(vault *tlockvault.Vault) (map[string]string, error) {
+	data := make(map[string]string)
+
+	for _, item := range form.Items {
+		// Get the value
+		value := strings.TrimSpace(item.FormItem.Value())
+
+		// If it is empty, lets replace with default value
+		if defaultValue, ok := form.Default[item.ID]; ok && value == "" {
+			value = defaultValue
+		}
+
+		// Run validators
+		for _, validator := range item.Validators {
+			if err := validator(vault, value); err != nil {
+				return data, err
+			}
+		}
+
+		data[item.ID] = value
+	}
+
+	return data, nil
+}
+
 // Returns the focused form item
 func (form *Form) FocusedItem() FormItem {
 	return form.Items[form.FocusedIndex].FormItem
@@ -173,6 +224,24 @@ func (form *Form) PostInit() {
 	form.Items[0].FormItem.Focus()
 }
 
+// Returns the form item with the given id
+func (form *Form) Item(id string) FormItem {
+	// Find the index
+	index := slices.IndexFunc(form.Items, func(item FormItemWrapped) bool { return item.ID == id })
+
+	// Return it
+	return form.Items[index].FormItem
+}
+
+// Sets the value of a form item
+func (form *Form) SetValue(id, value string) {
+	// Find the index
+	index := slices.IndexFunc(form.Items, func(item FormItemWrapped) bool { return item.ID == id })
+
+	// Set it
+	form.Items[index].FormItem.SetValue(value)
+}
+
 // Disables a form item
 func (form *Form) Disable(id string) {
 	// Find the index
@@ -20,6 +20,9 @@ type FormItem interface {
 	// Returns the value
 	Value() string
 
+	// Sets the value
+	SetValue(value string)
+
 	// Sets the error message
 	// Nil means to remove the error
 	SetError(err *error)
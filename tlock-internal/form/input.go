@@ -57,3 +57,8 @@ func (item *FormItemInputBox) SetError(err *error) {
 func (item FormItemInputBox) Value() string {
 	return item.Input.Value()
 }
+
+// SetValue
+func (item *FormItemInputBox) SetValue(value string) {
+	item.Input.SetValue(value)
+}
@@ -1,24 +1,14 @@
 package form
 
 import (
+	"slices"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	tlockstyles "github.com/eklairs/tlock/tlock/styles"
 )
 
-// Keybinding for moving the choosen item to right
-var KEY_RIGHT = key.NewBinding(
-	key.WithKeys("right"),
-	key.WithHelp("→", "right"),
-)
-
-// Keybinding for moving the choosen item to right
-var KEY_LEFT = key.NewBinding(
-	key.WithKeys("left"),
-	key.WithHelp("←", "left"),
-)
-
 // Form item for option box
 type FormItemOptionBox struct {
 	// Title
@@ -35,6 +25,12 @@ type FormItemOptionBox struct {
 
 	// Error message
 	Focused bool
+
+	// Keybinding for moving the choosen item to left
+	KeyLeft key.Binding
+
+	// Keybinding for moving the choosen item to right
+	KeyRight key.Binding
 }
 
 // Value
@@ -42,6 +38,14 @@ func (item FormItemOptionBox) Value() string {
 	return item.Values[item.SelectedIndex]
 }
 
+// SetValue
+// Values which are not one of the options are ignored
+func (item *FormItemOptionBox) SetValue(value string) {
+	if index := slices.Index(item.Values, value); index != -1 {
+		item.SelectedIndex = index
+	}
+}
+
 // SetError
 func (item FormItemOptionBox) SetError(err *error) {}
 
@@ -50,12 +54,12 @@ func (item *FormItemOptionBox) Update(msg tea.Msg) tea.Cmd {
 	switch msgType := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msgType, KEY_RIGHT):
+		case key.Matches(msgType, item.KeyRight):
 			if item.SelectedIndex != len(item.Values)-1 {
 				item.SelectedIndex += 1
 			}
 
-		case key.Matches(msgType, KEY_LEFT):
+		case key.Matches(msgType, item.KeyLeft):
 			if item.SelectedIndex != 0 {
 				item.SelectedIndex -= 1
 			}
@@ -38,3 +38,21 @@ type UserDeletedMsg struct{}
 type UserEditedMsg struct {
 	NewName string
 }
+
+// User config has been reloaded after it was changed
+type ConfigReloadedMsg struct{}
+
+// A code has been copied to the clipboard
+type ClipboardCopiedMsg struct {
+	// Time at which the clipboard is cleared, zero if it is never cleared
+	Deadline time.Time
+}
+
+// The clipboard clear timeout is over
+type ClipboardClearedMsg struct {
+	// Whether the clipboard was cleared, false if it was changed in the meantime
+	Cleared bool
+}
+
+// Requests to unselect the tokens, after a bulk action on them is done
+type ClearSelectionMsg struct{}
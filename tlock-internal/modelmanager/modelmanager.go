@@ -6,6 +6,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"golang.org/x/term"
 )
 
@@ -61,6 +62,11 @@ func New(rootScreen Screen) ModelManager {
 	}
 }
 
+// Calls the init method on the root screen
+func (manager ModelManager) Init() tea.Cmd {
+	return manager.stack[0].Init()
+}
+
 // Adds a new screen on the stack
 func (manager *ModelManager) PushScreen(screen Screen) tea.Cmd {
 	manager.operation = Operation{
@@ -100,7 +106,7 @@ func (manager *ModelManager) Update(msg tea.Msg) tea.Cmd {
 
 	// Update
 	switch msg.(type) {
-	case components.StatusBarMsg:
+	case components.StatusBarMsg, tlockmessages.ClipboardCopiedMsg, tlockmessages.ClipboardClearedMsg, tlockmessages.ConfigReloadedMsg:
 		// Send it to all the screens
 		for i := 0; i < len(manager.stack); i++ {
 			manager.stack[i], cmd = manager.stack[i].Update(msg, manager)
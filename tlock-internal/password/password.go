@@ -0,0 +1,162 @@
+package password
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Environment variable which can hold the password
+const ENV_PASSWORD = "TLOCK_PASSWORD"
+
+// Error representing that no password source is configured
+var ERR_NO_SOURCE = errors.New("No password source is configured")
+
+// Error representing that the password command is empty
+var ERR_EMPTY_COMMAND = errors.New("Password command cannot be empty")
+
+// Non-interactive sources of the vault password
+// In order of priority: file descriptor, file, environment variable and the password command
+type Sources struct {
+	// File descriptor to read the password from, -1 if unset
+	FD int
+
+	// File to read the password from
+	File string
+
+	// Password read from the file descriptor, it can only be read once
+	fdPassword *string
+}
+
+// Returns a new instance of sources with nothing configured
+func New() *Sources {
+	return &Sources{FD: -1}
+}
+
+// Registers the flags to the given flag set
+func (sources *Sources) Register(set *flag.FlagSet) {
+	set.IntVar(&sources.FD, "password-fd", -1, "Read the password from this file descriptor")
+	set.StringVar(&sources.File, "password-file", "", "Read the password from this file")
+}
+
+// Reads the password from the file descriptor right away
+// Should be called before anything else uses the descriptor, like the interactive app using stdin
+func (sources *Sources) ReadFD() error {
+	if sources.FD < 0 || sources.fdPassword != nil {
+		return nil
+	}
+
+	// Open file descriptor
+	file := os.NewFile(uintptr(sources.FD), fmt.Sprintf("fd %d", sources.FD))
+
+	if file == nil {
+		return fmt.Errorf("Invalid password file descriptor %d", sources.FD)
+	}
+
+	defer file.Close()
+
+	// Read
+	raw, err := io.ReadAll(file)
+
+	if err != nil {
+		return fmt.Errorf("Failed to read password from fd %d: %w", sources.FD, err)
+	}
+
+	password := trimNewline(string(raw))
+	sources.fdPassword = &password
+
+	return nil
+}
+
+// Returns the password from the first configured source
+// The command is the user's password command from the config, which is used when nothing else is configured
+// Returns ERR_NO_SOURCE if no source is configured
+func (sources *Sources) Resolve(command []string) (string, error) {
+	// File descriptor
+	if sources.FD >= 0 {
+		if err := sources.ReadFD(); err != nil {
+			return "", err
+		}
+
+		return *sources.fdPassword, nil
+	}
+
+	// File
+	if sources.File != "" {
+		raw, err := os.ReadFile(sources.File)
+
+		if err != nil {
+			return "", fmt.Errorf("Failed to read password file: %w", err)
+		}
+
+		return trimNewline(string(raw)), nil
+	}
+
+	// Environment variable
+	if password, ok := os.LookupEnv(ENV_PASSWORD); ok {
+		return password, nil
+	}
+
+	// Password command
+	if len(command) != 0 {
+		return runCommand(command)
+	}
+
+	return "", ERR_NO_SOURCE
+}
+
+// Returns if the password is given by the file descriptor, the file or the environment variable
+func (sources *Sources) hasSource() bool {
+	_, ok := os.LookupEnv(ENV_PASSWORD)
+
+	return sources.FD >= 0 || sources.File != "" || ok
+}
+
+// Runs the password command and returns its output
+// The command is executed directly, without a shell
+func runCommand(command []string) (string, error) {
+	return runCommandWith(command, nil, nil)
+}
+
+// Runs the password command with the given stdin and stderr, and returns its output
+// What the command writes to stderr is also kept to explain the failure
+func runCommandWith(command []string, stdin io.Reader, stderrOut io.Writer) (string, error) {
+	if command[0] == "" {
+		return "", ERR_EMPTY_COMMAND
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	// Run
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if stderrOut != nil {
+		cmd.Stderr = io.MultiWriter(&stderr, stderrOut)
+	}
+
+	if err := cmd.Run(); err != nil {
+		// Include what the command said about the failure
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("Password command failed: %w: %s", err, message)
+		}
+
+		return "", fmt.Errorf("Password command failed: %w", err)
+	}
+
+	return trimNewline(stdout.String()), nil
+}
+
+// Removes the trailing newline, which is added by most of the tools and editors
+func trimNewline(value string) string {
+	value = strings.TrimSuffix(value, "\n")
+
+	return strings.TrimSuffix(value, "\r")
+}
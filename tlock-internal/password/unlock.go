@@ -0,0 +1,99 @@
+package password
+
+import (
+	"io"
+
+	tea "github.com/charmbracelet/bubbletea"
+	tlockcore "github.com/eklairs/tlock/tlock-core"
+	"github.com/eklairs/tlock/tlock-internal/config"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+)
+
+// Unlocks the vault of the user without asking for the password
+// The vault is first tried with an empty password, and then with the password from the sources
+// Returns ERR_NO_SOURCE if the vault is protected and no source is configured
+func (sources *Sources) Unlock(user tlockcore.User) (*tlockvault.Vault, error) {
+	// Try to unlock without any password
+	if vault, err := tlockvault.Load(user.Vault(), ""); err == nil {
+		return vault, nil
+	}
+
+	// Get password, the config falls back to the defaults if it is invalid
+	userConfig, _ := config.LoadUserConfig(user.S())
+	password, err := sources.Resolve(userConfig.PasswordCommand)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tlockvault.Load(user.Vault(), password)
+}
+
+// Same as Unlock, but for the interactive app, where the result is turned into a message by the callback
+// It runs in the background, and the terminal is released while the password command runs, as helpers like pinentry ask on it
+func (sources *Sources) UnlockCmd(user tlockcore.User, fn func(*tlockvault.Vault, error) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		// Try to unlock without any password
+		if vault, err := tlockvault.Load(user.Vault(), ""); err == nil {
+			return fn(vault, nil)
+		}
+
+		// Get password command, the config falls back to the defaults if it is invalid
+		userConfig, _ := config.LoadUserConfig(user.S())
+
+		// Other sources do not need the terminal
+		if sources.hasSource() || len(userConfig.PasswordCommand) == 0 {
+			password, err := sources.Resolve(userConfig.PasswordCommand)
+
+			if err != nil {
+				return fn(nil, err)
+			}
+
+			return fn(tlockvault.Load(user.Vault(), password))
+		}
+
+		command := &terminalCommand{command: userConfig.PasswordCommand}
+
+		return tea.Exec(command, func(err error) tea.Msg {
+			if err != nil {
+				return fn(nil, err)
+			}
+
+			return fn(tlockvault.Load(user.Vault(), command.password))
+		})()
+	}
+}
+
+// Password command which is ran with the terminal by the interactive app
+type terminalCommand struct {
+	// Command to run
+	command []string
+
+	// Terminal
+	stdin  io.Reader
+	stderr io.Writer
+
+	// Output of the command
+	password string
+}
+
+// Run
+func (command *terminalCommand) Run() error {
+	password, err := runCommandWith(command.command, command.stdin, command.stderr)
+	command.password = password
+
+	return err
+}
+
+// SetStdin
+func (command *terminalCommand) SetStdin(stdin io.Reader) {
+	command.stdin = stdin
+}
+
+// SetStdout, the output is the password, which is not shown
+func (command *terminalCommand) SetStdout(io.Writer) {}
+
+// SetStderr
+func (command *terminalCommand) SetStderr(stderr io.Writer) {
+	command.stderr = stderr
+}
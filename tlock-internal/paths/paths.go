@@ -18,6 +18,12 @@ var VAULT_DIR = path.Join(DATA_BASE, "vaults")
 // Path to the list of users
 var USERS = path.Join(DATA_BASE, "users.bin")
 
+// Path to the global config, which is used before logging in
+var GLOBAL_CONFIG = path.Join(CONFIG_BASE, "config.yaml")
+
+// Directory that contains custom themes
+var THEMES_DIR = path.Join(CONFIG_BASE, "themes")
+
 // Path to tlock internal config file
 var TLOCK_CONFIG = path.Join(CONFIG_BASE, "config_internal_ignore.bin")
 
@@ -25,3 +31,8 @@ var TLOCK_CONFIG = path.Join(CONFIG_BASE, "config_internal_ignore.bin")
 func UserConfigFor(username string) string {
 	return path.Join(CONFIG_BASE, username, "config.yaml")
 }
+
+// Returns the path to the given user's tlock internal config file
+func UserTLockConfigFor(username string) string {
+	return path.Join(CONFIG_BASE, username, "config_internal_ignore.bin")
+}
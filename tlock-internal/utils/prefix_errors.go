@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Prefixes the error, or each of the errors if they were joined together
+func PrefixErrors(prefix string, err error) error {
+	joined, ok := err.(interface{ Unwrap() []error })
+
+	if !ok {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+
+	return errors.Join(Map(joined.Unwrap(), func(err error) error { return fmt.Errorf("%s: %w", prefix, err) })...)
+}
@@ -0,0 +1,226 @@
+package tlockvault
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Shown instead of the secrets in the audit log
+const REDACTED = "<redacted>"
+
+// Sets who makes the changes, which is recorded in the audit log
+func (vault *Vault) SetActor(actor string) {
+	vault.actor = actor
+}
+
+// Records a change in the audit log
+// It is written along with the next write
+func (vault *Vault) logAudit(action string, changes []AuditChange) {
+	vault.Audit = append(vault.Audit, AuditEntry{Time: time.Now(), Actor: vault.actor, Action: action, Changes: changes})
+}
+
+// Records the next write as the given action, compared to the state before it
+func (vault *Vault) auditNext(before vaultSnapshot, action string) {
+	before.description = action
+	vault.pendingAudit = &before
+}
+
+// Returns the name of the token in the audit log, along with its ID to tell which seed it is
+func auditTokenName(token Token) string {
+	name := token.Account
+
+	if name == "" {
+		name = "<no account name>"
+	}
+
+	if token.Issuer != "" {
+		name = fmt.Sprintf("%s • %s", name, token.Issuer)
+	}
+
+	return fmt.Sprintf("%s (%s)", name, token.ID())
+}
+
+// Returns the type of the token as text
+func auditTokenType(type_ TokenType) string {
+	if type_ == TokenTypeHOTP {
+		return "hotp"
+	}
+
+	return "totp"
+}
+
+// Returns the changed fields of the token, the secret is redacted
+func diffTokens(target string, before, after Token, beforeFolder, afterFolder string) []AuditChange {
+	changes := make([]AuditChange, 0)
+
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, AuditChange{Target: target, Field: field, Old: old, New: new})
+		}
+	}
+
+	if before.Secret != after.Secret {
+		changes = append(changes, AuditChange{
+			Target: target,
+			Field:  "secret",
+			Old:    fmt.Sprintf("%s (%s)", REDACTED, before.ID()),
+			New:    fmt.Sprintf("%s (%s)", REDACTED, after.ID()),
+		})
+	}
+
+	add("issuer", before.Issuer, after.Issuer)
+	add("account", before.Account, after.Account)
+	add("type", auditTokenType(before.Type), auditTokenType(after.Type))
+	add("digits", strconv.Itoa(before.Digits), strconv.Itoa(after.Digits))
+	add("period", strconv.Itoa(before.Period), strconv.Itoa(after.Period))
+	add("algorithm", before.HashingAlgorithm.String(), after.HashingAlgorithm.String())
+	add("initial counter", strconv.Itoa(before.InitialCounter), strconv.Itoa(after.InitialCounter))
+	add("tags", strings.Join(before.Tags, ", "), strings.Join(after.Tags, ", "))
+	add("icon", before.Icon, after.Icon)
+	add("favorite", strconv.FormatBool(before.Favorite), strconv.FormatBool(after.Favorite))
+	add("folder", beforeFolder, afterFolder)
+
+	return changes
+}
+
+// Token along with the folder it is in
+type auditToken struct {
+	token  Token
+	folder string
+}
+
+// Returns the tokens of the folders by their secret, and the secrets in the order they are stored
+func auditTokens(folders []Folder) (map[string]auditToken, []string) {
+	tokens := make(map[string]auditToken)
+	secrets := make([]string, 0)
+
+	for _, folder := range folders {
+		for _, token := range folder.Tokens {
+			tokens[token.Secret] = auditToken{token: token, folder: folder.Name}
+			secrets = append(secrets, token.Secret)
+		}
+	}
+
+	return tokens, secrets
+}
+
+// Returns the changes between the two states of the vault
+// A single removed and added token, folder or smart folder is reported as an edit, like a changed secret or a rename
+func diffSnapshots(before, after vaultSnapshot) []AuditChange {
+	changes := make([]AuditChange, 0)
+
+	// Tokens
+	beforeTokens, beforeSecrets := auditTokens(before.folders)
+	afterTokens, afterSecrets := auditTokens(after.folders)
+
+	removed := slices.DeleteFunc(slices.Clone(beforeSecrets), func(secret string) bool { _, ok := afterTokens[secret]; return ok })
+	added := slices.DeleteFunc(slices.Clone(afterSecrets), func(secret string) bool { _, ok := beforeTokens[secret]; return ok })
+
+	for _, secret := range beforeSecrets {
+		if after, ok := afterTokens[secret]; ok {
+			before := beforeTokens[secret]
+			changes = append(changes, diffTokens(auditTokenName(before.token), before.token, after.token, before.folder, after.folder)...)
+		}
+	}
+
+	if len(removed) == 1 && len(added) == 1 {
+		before, after := beforeTokens[removed[0]], afterTokens[added[0]]
+		changes = append(changes, diffTokens(auditTokenName(before.token), before.token, after.token, before.folder, after.folder)...)
+	} else {
+		for _, secret := range removed {
+			changes = append(changes, AuditChange{Target: auditTokenName(beforeTokens[secret].token), Field: "token", Old: beforeTokens[secret].folder})
+		}
+
+		for _, secret := range added {
+			changes = append(changes, AuditChange{Target: auditTokenName(afterTokens[secret].token), Field: "token", New: afterTokens[secret].folder})
+		}
+	}
+
+	// Folders
+	changes = append(changes, diffNamed(
+		before.folders, after.folders,
+		func(folder Folder) string { return folder.Name },
+		func(folder Folder) string { return fmt.Sprintf("%s folder", folder.Name) },
+		func(target string, before, after Folder) []AuditChange {
+			changes := make([]AuditChange, 0)
+
+			if before.Parent != after.Parent {
+				changes = append(changes, AuditChange{Target: target, Field: "parent", Old: before.Parent, New: after.Parent})
+			}
+
+			if before.SortMode != after.SortMode {
+				changes = append(changes, AuditChange{Target: target, Field: "sort mode", Old: before.SortMode.String(), New: after.SortMode.String()})
+			}
+
+			return changes
+		},
+	)...)
+
+	// Smart folders
+	changes = append(changes, diffNamed(
+		before.smartFolders, after.smartFolders,
+		func(folder SmartFolder) string { return folder.Name },
+		func(folder SmartFolder) string { return fmt.Sprintf("%s smart folder", folder.Name) },
+		func(target string, before, after SmartFolder) []AuditChange {
+			if before.Query == after.Query {
+				return nil
+			}
+
+			return []AuditChange{{Target: target, Field: "query", Old: before.Query, New: after.Query}}
+		},
+	)...)
+
+	// Trash
+	if len(before.trash) != len(after.trash) {
+		changes = append(changes, AuditChange{Target: "trash", Field: "items", Old: strconv.Itoa(len(before.trash)), New: strconv.Itoa(len(after.trash))})
+	}
+
+	return changes
+}
+
+// Returns the changes between two lists of items with a name
+func diffNamed[T any](before, after []T, name func(T) string, target func(T) string, diff func(string, T, T) []AuditChange) []AuditChange {
+	changes := make([]AuditChange, 0)
+
+	find := func(items []T, wanted string) int {
+		return slices.IndexFunc(items, func(item T) bool { return name(item) == wanted })
+	}
+
+	removed := make([]T, 0)
+	added := make([]T, 0)
+
+	for _, item := range before {
+		if index := find(after, name(item)); index != -1 {
+			changes = append(changes, diff(target(item), item, after[index])...)
+		} else {
+			removed = append(removed, item)
+		}
+	}
+
+	for _, item := range after {
+		if find(before, name(item)) == -1 {
+			added = append(added, item)
+		}
+	}
+
+	// Renamed
+	if len(removed) == 1 && len(added) == 1 {
+		changes = append(changes, AuditChange{Target: target(removed[0]), Field: "name", Old: name(removed[0]), New: name(added[0])})
+		changes = append(changes, diff(target(removed[0]), removed[0], added[0])...)
+
+		return changes
+	}
+
+	for _, item := range removed {
+		changes = append(changes, AuditChange{Target: target(item), Field: "name", Old: name(item)})
+	}
+
+	for _, item := range added {
+		changes = append(changes, AuditChange{Target: target(item), Field: "name", New: name(item)})
+	}
+
+	return changes
+}
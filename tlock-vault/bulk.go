@@ -0,0 +1,89 @@
+package tlockvault
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/eklairs/tlock/tlock-internal/utils"
+)
+
+// Moves the tokens to the given folder, from whichever folder they are in
+// The vault is written once for the whole batch
+func (vault *Vault) MoveTokens(tokens []Token, toFolder string) error {
+	destination := vault.findFolder(toFolder)
+
+	if destination == -1 {
+		return ERR_FOLDER_NOT_FOUND
+	}
+
+	vault.checkpoint(fmt.Sprintf("moved %s to %s", describeTokens(tokens), toFolder))
+
+	for _, token := range tokens {
+		folder, index := vault.locateTokenAnywhere(token)
+
+		// Skip the ones which are gone or already there
+		if folder == -1 || folder == destination {
+			continue
+		}
+
+		moved := vault.Folders[folder].Tokens[index]
+
+		vault.Folders[folder].Tokens = utils.Remove(vault.Folders[folder].Tokens, index)
+		vault.Folders[destination].Tokens = append(vault.Folders[destination].Tokens, moved)
+	}
+
+	// Write
+	vault.write()
+
+	return nil
+}
+
+// Deletes the tokens, from whichever folder they are in, they are moved to the trash
+// The vault is written once for the whole batch
+func (vault *Vault) DeleteTokens(tokens []Token) {
+	vault.checkpoint(fmt.Sprintf("deleted %s", describeTokens(tokens)))
+
+	for _, token := range tokens {
+		if folder, index := vault.locateTokenAnywhere(token); folder != -1 {
+			vault.trashToken(folder, index)
+			vault.Folders[folder].Tokens = utils.Remove(vault.Folders[folder].Tokens, index)
+		}
+	}
+
+	// Write
+	vault.write()
+}
+
+// Adds the tags to the tokens, skipping the ones they already have
+// The vault is written once for the whole batch
+func (vault *Vault) TagTokens(tokens []Token, tags []string) {
+	vault.checkpoint(fmt.Sprintf("tagged %s", describeTokens(tokens)))
+
+	for _, token := range tokens {
+		folder, index := vault.locateTokenAnywhere(token)
+
+		if folder == -1 {
+			continue
+		}
+
+		for _, tag := range tags {
+			if !slices.Contains(vault.Folders[folder].Tokens[index].Tags, tag) {
+				vault.Folders[folder].Tokens[index].Tags = append(vault.Folders[folder].Tokens[index].Tags, tag)
+			}
+		}
+	}
+
+	// Write
+	vault.write()
+}
+
+// Finds the index of the folder and the token, searching every folder
+func (vault *Vault) locateTokenAnywhere(token Token) (int, int) {
+	for i := 0; i < len(vault.Folders); i++ {
+		if index := vault.findToken(i, token.Secret); index != -1 {
+			return i, index
+		}
+	}
+
+	return -1, -1
+}
@@ -0,0 +1,50 @@
+package tlockvault
+
+import (
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+)
+
+// Returns the remaining time in seconds before the code changes
+// Only meaningful for TOTP based tokens
+func (token Token) RemainingTime() int {
+	return int(token.Period - int(time.Now().Unix())%token.Period)
+}
+
+// Returns the current code for the token
+func (token Token) CurrentCode() string {
+	return token.codeAt(time.Now(), 0)
+}
+
+// Returns the code after the current one
+// It is the code of the next period for TOTP based tokens, and of the next counter for HOTP based tokens
+func (token Token) NextCode() string {
+	if token.Type == TokenTypeTOTP {
+		return token.codeAt(time.Now().Add(time.Duration(token.Period)*time.Second), 0)
+	}
+
+	return token.codeAt(time.Now(), 1)
+}
+
+// Returns the code at the given time, or at the given number of uses after the current counter
+func (token Token) codeAt(at time.Time, uses int) string {
+	var code string
+
+	if token.Type == TokenTypeTOTP {
+		code, _ = totp.GenerateCodeCustom(token.Secret, at, totp.ValidateOpts{
+			Period:    uint(token.Period),
+			Digits:    otp.Digits(token.Digits),
+			Algorithm: token.HashingAlgorithm,
+		})
+	} else {
+		code, _ = hotp.GenerateCodeCustom(token.Secret, uint64(token.UsageCounter+token.InitialCounter+uses), hotp.ValidateOpts{
+			Digits:    otp.Digits(token.Digits),
+			Algorithm: token.HashingAlgorithm,
+		})
+	}
+
+	return code
+}
@@ -0,0 +1,41 @@
+package tlockvault
+
+import "fmt"
+
+// Name of the virtual folder with the favorite tokens
+const FAVORITES_FOLDER = "★ Favorites"
+
+// Query that picks the favorite tokens
+const FAVORITES_QUERY = "is:favorite"
+
+// Returns the virtual folder with the favorite tokens of every folder
+func (vault *Vault) Favorites() Folder {
+	favorites := vault.VirtualFolder(FAVORITES_FOLDER, FAVORITES_QUERY)
+	favorites.Favorites = true
+
+	return favorites
+}
+
+// Marks the token as favorite, or unmarks it if it already is one
+// Returns if the token is a favorite now
+func (vault *Vault) ToggleFavorite(folder string, token Token) bool {
+	name := describeTokens([]Token{token})
+
+	// Find the folder
+	if folder, token := vault.locateToken(folder, token); folder != -1 && token != -1 {
+		if vault.Folders[folder].Tokens[token].Favorite {
+			vault.checkpoint(fmt.Sprintf("removed %s from the favorites", name))
+		} else {
+			vault.checkpoint(fmt.Sprintf("added %s to the favorites", name))
+		}
+
+		vault.Folders[folder].Tokens[token].Favorite = !vault.Folders[folder].Tokens[token].Favorite
+
+		// Write
+		vault.write()
+
+		return vault.Folders[folder].Tokens[token].Favorite
+	}
+
+	return false
+}
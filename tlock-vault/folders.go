@@ -1,6 +1,7 @@
 package tlockvault
 
 import (
+	"fmt"
 	"slices"
 
 	"github.com/eklairs/tlock/tlock-internal/utils"
@@ -12,6 +13,8 @@ func (vault *Vault) AddFolder(name string) error {
 
 	// Validate
 	if name, err = vault.validateFolderName(name); err == nil {
+		vault.checkpoint(fmt.Sprintf("added the %s folder", name))
+
 		// Add folder
 		vault.Folders = append(vault.Folders, Folder{Name: name})
 
@@ -29,9 +32,18 @@ func (vault *Vault) RenameFolder(old, newName string) error {
 
 	// Validate folder name
 	if newName, err = vault.validateFolderName(newName); err == nil {
+		vault.checkpoint(fmt.Sprintf("renamed the %s folder to %s", old, newName))
+
 		// Update
 		vault.Folders[vault.findFolder(old)].Name = newName
 
+		// Keep the subfolders inside of it
+		for index := range vault.Folders {
+			if vault.Folders[index].Parent == old {
+				vault.Folders[index].Parent = newName
+			}
+		}
+
 		// Write
 		vault.write()
 	}
@@ -45,10 +57,21 @@ func (vault *Vault) GetTokens(folder string) []Token {
 	return vault.Folders[vault.findFolder(folder)].Tokens
 }
 
-// Deletes a folder by its name
+// Deletes a folder by its name, it is moved to the trash with its tokens
+// Its subfolders are moved to its parent
 func (vault *Vault) DeleteFolder(name string) {
 	if index := vault.findFolder(name); index != -1 {
-		// Remove
+		vault.checkpoint(fmt.Sprintf("deleted the %s folder", name))
+
+		// Move the subfolders up
+		for i := range vault.Folders {
+			if vault.Folders[i].Parent == name {
+				vault.Folders[i].Parent = vault.Folders[index].Parent
+			}
+		}
+
+		// Remove, along with its tokens
+		vault.trashFolder(index)
 		vault.Folders = utils.Remove(vault.Folders, index)
 
 		// Write
@@ -56,12 +79,16 @@ func (vault *Vault) DeleteFolder(name string) {
 	}
 }
 
-// Moves the folder up
+// Moves the folder above its previous sibling
 func (vault *Vault) MoveFolderUp(name string) bool {
+	index := vault.findFolder(name)
+
 	// We will skip if the folder is already at top
-	if index := vault.findFolder(name); index != 0 {
+	if sibling := vault.siblingOf(index, -1); index != -1 && sibling != -1 {
+		vault.checkpoint(fmt.Sprintf("moved the %s folder up", name))
+
 		// Swap
-		vault.Folders = utils.Swap(vault.Folders, index, index-1)
+		vault.Folders = utils.Swap(vault.Folders, index, sibling)
 
 		// Wrap
 		vault.write()
@@ -73,12 +100,16 @@ func (vault *Vault) MoveFolderUp(name string) bool {
 	return false
 }
 
-// Moves the folder down
+// Moves the folder below its next sibling
 func (vault *Vault) MoveFolderDown(name string) bool {
+	index := vault.findFolder(name)
+
 	// We will skip if the folder is already at bottom
-	if index := vault.findFolder(name); index != len(vault.Folders)-1 {
+	if sibling := vault.siblingOf(index, 1); index != -1 && sibling != -1 {
+		vault.checkpoint(fmt.Sprintf("moved the %s folder down", name))
+
 		// Swap
-		vault.Folders = utils.Swap(vault.Folders, index, index+1)
+		vault.Folders = utils.Swap(vault.Folders, index, sibling)
 
 		// Wrap
 		vault.write()
@@ -0,0 +1,111 @@
+package tlockvault
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/kelindar/binary"
+	"github.com/pquerna/otp"
+)
+
+// Version of the format of the data inside of the vault
+// The vaults written before the format was versioned hold the folders in a binary format
+// It is increased whenever data is added, so older versions refuse the vault instead of dropping the data
+// 3: favorite tokens
+// 4: tags of the tokens and smart folders
+// 5: parents of the folders
+// 6: trash
+// 7: audit log
+// 8: icons of the tokens
+const VAULT_VERSION = 8
+
+// Error representing that the vault was written by a newer version of tlock
+var ERR_VAULT_TOO_NEW = errors.New("The vault was saved by a newer version of tlock, please update it")
+
+// Data inside of the vault
+type vaultData struct {
+	// Version of the format
+	Version int `json:"version"`
+
+	// All the folders
+	Folders []Folder `json:"folders"`
+
+	// Folders defined by a query
+	SmartFolders []SmartFolder `json:"smart_folders"`
+
+	// Deleted tokens and folders
+	Trash []TrashItem `json:"trash"`
+
+	// Every change made to the vault
+	Audit []AuditEntry `json:"audit"`
+}
+
+// Token as stored in the binary format
+type legacyToken struct {
+	Type             TokenType
+	Issuer           string
+	Account          string
+	Secret           string
+	InitialCounter   int
+	Period           int
+	Digits           int
+	HashingAlgorithm otp.Algorithm
+	UsageCounter     int
+}
+
+// Folder as stored in the binary format
+type legacyFolder struct {
+	Name   string
+	Tokens []legacyToken
+}
+
+// Serializes the data in the current format
+func encodeVault(data vaultData) ([]byte, error) {
+	data.Version = VAULT_VERSION
+
+	return json.Marshal(data)
+}
+
+// Deserializes the data, in either the current or the binary format
+func decodeVault(data []byte) (vaultData, error) {
+	var decoded vaultData
+
+	// Current format
+	if err := json.Unmarshal(data, &decoded); err == nil {
+		// Writing it back would drop the fields which are not known to this version
+		if decoded.Version > VAULT_VERSION {
+			return vaultData{}, ERR_VAULT_TOO_NEW
+		}
+
+		return decoded, nil
+	}
+
+	// Binary format
+	var legacy []legacyFolder
+
+	if err := binary.Unmarshal(data, &legacy); err != nil {
+		return decoded, err
+	}
+
+	folders := make([]Folder, len(legacy))
+
+	for index, folder := range legacy {
+		folders[index] = Folder{Name: folder.Name, Tokens: make([]Token, len(folder.Tokens))}
+
+		for tokenIndex, token := range folder.Tokens {
+			folders[index].Tokens[tokenIndex] = Token{
+				Type:             token.Type,
+				Issuer:           token.Issuer,
+				Account:          token.Account,
+				Secret:           token.Secret,
+				InitialCounter:   token.InitialCounter,
+				Period:           token.Period,
+				Digits:           token.Digits,
+				HashingAlgorithm: token.HashingAlgorithm,
+				UsageCounter:     token.UsageCounter,
+			}
+		}
+	}
+
+	return vaultData{Folders: folders}, nil
+}
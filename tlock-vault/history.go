@@ -0,0 +1,165 @@
+package tlockvault
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Maximum number of changes that can be undone
+const HISTORY_LIMIT = 100
+
+// Error representing that there is no change to undo
+var ERR_NOTHING_TO_UNDO = errors.New("Nothing to undo")
+
+// Error representing that there is no change to redo
+var ERR_NOTHING_TO_REDO = errors.New("Nothing to redo")
+
+// State of the vault before a change
+type vaultSnapshot struct {
+	// What the change did, like `deleted the Work folder`
+	description string
+
+	// Folders
+	folders []Folder
+
+	// Smart folders
+	smartFolders []SmartFolder
+
+	// Trash, its items are never changed so they are shared
+	trash []TrashItem
+}
+
+// Changes made in this session, which can be undone and redone
+// It is not stored in the vault file
+type history struct {
+	// Changes that can be undone, the latest one is the last
+	undo []vaultSnapshot
+
+	// Changes that were undone and can be redone, the latest one is the last
+	redo []vaultSnapshot
+}
+
+// Describes the tokens for the history, like `the alice token` or `3 tokens`
+func describeTokens(tokens []Token) string {
+	if len(tokens) != 1 {
+		return fmt.Sprintf("%d tokens", len(tokens))
+	}
+
+	if tokens[0].Account == "" {
+		return "the <no account name> token"
+	}
+
+	return fmt.Sprintf("the %s token", tokens[0].Account)
+}
+
+// Saves the state of the vault before a change, so that it can be undone
+// Must be called right before the change, after it is validated
+func (vault *Vault) checkpoint(description string) {
+	if vault.history == nil {
+		vault.history = &history{}
+	}
+
+	snapshot := vault.snapshot(description)
+
+	vault.history.undo = append(vault.history.undo, snapshot)
+	vault.pendingAudit = &snapshot
+
+	// Forget the oldest change
+	if len(vault.history.undo) > HISTORY_LIMIT {
+		vault.history.undo = vault.history.undo[1:]
+	}
+
+	// A new change discards the undone ones
+	vault.history.redo = nil
+}
+
+// Forgets every change of this session, so none of them can be undone or redone
+func (vault *Vault) forgetHistory() {
+	vault.history = nil
+}
+
+// Undoes the last change
+// Returns what the change did
+func (vault *Vault) Undo() (string, error) {
+	if vault.history == nil || len(vault.history.undo) == 0 {
+		return "", ERR_NOTHING_TO_UNDO
+	}
+
+	snapshot := vault.history.undo[len(vault.history.undo)-1]
+	vault.history.undo = vault.history.undo[:len(vault.history.undo)-1]
+
+	// The current state is where redo goes back to
+	current := vault.snapshot(snapshot.description)
+
+	vault.history.redo = append(vault.history.redo, current)
+	vault.auditNext(current, "undid: "+snapshot.description)
+	vault.restore(snapshot)
+
+	return snapshot.description, nil
+}
+
+// Redoes the last undone change
+// Returns what the change did
+func (vault *Vault) Redo() (string, error) {
+	if vault.history == nil || len(vault.history.redo) == 0 {
+		return "", ERR_NOTHING_TO_REDO
+	}
+
+	snapshot := vault.history.redo[len(vault.history.redo)-1]
+	vault.history.redo = vault.history.redo[:len(vault.history.redo)-1]
+
+	// The current state is where undo goes back to
+	current := vault.snapshot(snapshot.description)
+
+	vault.history.undo = append(vault.history.undo, current)
+	vault.auditNext(current, "redid: "+snapshot.description)
+	vault.restore(snapshot)
+
+	return snapshot.description, nil
+}
+
+// Returns a copy of the current state, which is not changed by the later changes
+func (vault *Vault) snapshot(description string) vaultSnapshot {
+	folders := make([]Folder, len(vault.Folders))
+
+	for i, folder := range vault.Folders {
+		folder.Tokens = slices.Clone(folder.Tokens)
+
+		for j := range folder.Tokens {
+			folder.Tokens[j].Tags = slices.Clone(folder.Tokens[j].Tags)
+		}
+
+		folders[i] = folder
+	}
+
+	return vaultSnapshot{description: description, folders: folders, smartFolders: slices.Clone(vault.SmartFolders), trash: slices.Clone(vault.Trash)}
+}
+
+// Brings the vault back to the snapshot
+// The HOTP counters and the usage of the tokens are kept, a used code must never come back
+func (vault *Vault) restore(snapshot vaultSnapshot) {
+	// Current tokens by their secret
+	current := make(map[string]Token)
+
+	for _, folder := range vault.Folders {
+		for _, token := range folder.Tokens {
+			current[token.Secret] = token
+		}
+	}
+
+	for i := range snapshot.folders {
+		for j, token := range snapshot.folders[i].Tokens {
+			if latest, ok := current[token.Secret]; ok {
+				snapshot.folders[i].Tokens[j].UsageCounter = latest.UsageCounter
+				snapshot.folders[i].Tokens[j].CopyCount = latest.CopyCount
+				snapshot.folders[i].Tokens[j].LastUsed = latest.LastUsed
+			}
+		}
+	}
+
+	vault.Folders, vault.SmartFolders, vault.Trash = snapshot.folders, snapshot.smartFolders, snapshot.trash
+
+	// Write
+	vault.write()
+}
@@ -4,8 +4,6 @@ import (
 	"errors"
 	"os"
 	"path"
-
-	"github.com/kelindar/binary"
 )
 
 // Error represents the vault may be been moved or deleted
@@ -25,7 +23,7 @@ func Initialize(at, password string) (*Vault, error) {
 	vault := Vault{
 		path:     at,
 		password: password,
-		dataChan: make(chan []Folder, 1),
+		dataChan: make(chan vaultData, 1),
 	}
 
 	// Run post init hook
@@ -53,25 +51,31 @@ func Load(path, password string) (*Vault, error) {
 		return nil, ERR_VAULT_DELETED
 	}
 
-	// Empty data
-	var data []Folder
-
 	// Decrypt
 	if decrypted, err = Decrypt(password, raw); err != nil {
 		return nil, ERR_PASSWORD_INVALID
 	}
 
-	// Unmarshal binary serialized data
-	if err := binary.Unmarshal(decrypted, &data); err != nil {
+	// Deserialize the data, in any of the formats
+	data, err := decodeVault(decrypted)
+
+	if errors.Is(err, ERR_VAULT_TOO_NEW) {
+		return nil, err
+	}
+
+	if err != nil {
 		return nil, ERR_PASSWORD_INVALID
 	}
 
 	// Create vault instance and return
 	vault := &Vault{
-		path:     path,
-		Folders:  data,
-		password: password,
-		dataChan: make(chan []Folder, 1),
+		path:         path,
+		Folders:      data.Folders,
+		SmartFolders: data.SmartFolders,
+		Trash:        data.Trash,
+		Audit:        data.Audit,
+		password:     password,
+		dataChan:     make(chan vaultData, 1),
 	}
 
 	// Run post init hook
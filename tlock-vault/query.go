@@ -0,0 +1,168 @@
+package tlockvault
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Error representing that the query is empty
+var ERR_QUERY_EMPTY = errors.New("Query cannot be empty")
+
+// Error representing that the query uses a field that does not exist
+var ERR_QUERY_FIELD = errors.New("Unknown field, use issuer, account, tag, type, folder or is")
+
+// Error representing that a field in the query has no value
+var ERR_QUERY_VALUE = errors.New("Fields need a value, like issuer:github")
+
+// Error representing that the type in the query is invalid
+var ERR_QUERY_TYPE = errors.New("Type can only be totp or hotp")
+
+// Error representing that the is: field in the query is invalid
+var ERR_QUERY_IS = errors.New("Only is:favorite is supported")
+
+// Fields that can be used in a query
+var QUERY_FIELDS = []string{"issuer", "account", "tag", "type", "folder", "is"}
+
+// Single condition of a query, like `issuer:aws` or `-tag:old`
+type queryTerm struct {
+	// Field, empty for plain words which match the issuer or the account
+	field string
+
+	// Lowercased value
+	value string
+
+	// Whether the term must not match
+	negate bool
+}
+
+// Parsed query, a token matches it if it matches all the terms
+type Query []queryTerm
+
+// Parses a query like `issuer:aws tag:prod type:totp`
+func ParseQuery(query string) (Query, error) {
+	parsed := make(Query, 0)
+
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		term := queryTerm{}
+
+		// Negated term
+		if strings.HasPrefix(word, "-") && len(word) > 1 {
+			term.negate = true
+			word = word[1:]
+		}
+
+		term.value = word
+
+		// Field and value
+		if field, value, ok := strings.Cut(word, ":"); ok {
+			if !slices.Contains(QUERY_FIELDS, field) {
+				return nil, ERR_QUERY_FIELD
+			}
+
+			if value == "" {
+				return nil, ERR_QUERY_VALUE
+			}
+
+			if field == "type" && value != "totp" && value != "hotp" {
+				return nil, ERR_QUERY_TYPE
+			}
+
+			if field == "is" && value != "favorite" {
+				return nil, ERR_QUERY_IS
+			}
+
+			term.field, term.value = field, value
+		}
+
+		parsed = append(parsed, term)
+	}
+
+	if len(parsed) == 0 {
+		return nil, ERR_QUERY_EMPTY
+	}
+
+	return parsed, nil
+}
+
+// Returns if the token in the given folder matches the query
+func (query Query) Matches(folder string, token Token) bool {
+	for _, term := range query {
+		if term.matches(folder, token) == term.negate {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns if the token in the given folder matches the term, ignoring the negation
+func (term queryTerm) matches(folder string, token Token) bool {
+	contains := func(text string) bool {
+		return strings.Contains(strings.ToLower(text), term.value)
+	}
+
+	switch term.field {
+	case "issuer":
+		return contains(token.Issuer)
+
+	case "account":
+		return contains(token.Account)
+
+	case "folder":
+		return contains(folder)
+
+	case "tag":
+		return slices.ContainsFunc(token.Tags, func(tag string) bool { return strings.ToLower(tag) == term.value })
+
+	case "type":
+		return (term.value == "hotp") == (token.Type == TokenTypeHOTP)
+
+	case "is":
+		return token.Favorite
+	}
+
+	return contains(token.Issuer) || contains(token.Account)
+}
+
+// Returns the tokens of every folder that match the query
+// Nothing matches an invalid query
+func (vault *Vault) QueryTokens(query string) []Token {
+	tokens := make([]Token, 0)
+
+	parsed, err := ParseQuery(query)
+
+	if err != nil {
+		return tokens
+	}
+
+	for _, folder := range vault.Folders {
+		for _, token := range folder.Tokens {
+			if parsed.Matches(folder.Name, token) {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	return tokens
+}
+
+// Returns a virtual folder with the tokens that match the query
+func (vault *Vault) VirtualFolder(name, query string) Folder {
+	return Folder{Name: name, Tokens: vault.QueryTokens(query), Virtual: true, Query: query}
+}
+
+// Splits a comma separated list of tags, dropping the empty and duplicate ones
+func ParseTags(tags string) []string {
+	parsed := make([]string, 0)
+
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if tag != "" && !slices.Contains(parsed, tag) {
+			parsed = append(parsed, tag)
+		}
+	}
+
+	return parsed
+}
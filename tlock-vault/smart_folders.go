@@ -0,0 +1,83 @@
+package tlockvault
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/eklairs/tlock/tlock-internal/utils"
+)
+
+// Adds a new smart folder with the given query
+func (vault *Vault) AddSmartFolder(name, query string) error {
+	var err error
+
+	// Validate
+	if name, err = vault.validateFolderName(name); err != nil {
+		return err
+	}
+
+	if _, err = ParseQuery(query); err != nil {
+		return err
+	}
+
+	vault.checkpoint(fmt.Sprintf("added the %s smart folder", name))
+
+	// Add folder
+	vault.SmartFolders = append(vault.SmartFolders, SmartFolder{Name: name, Query: query})
+
+	// Write
+	vault.write()
+
+	return nil
+}
+
+// Changes the name and the query of the smart folder
+func (vault *Vault) EditSmartFolder(old, name, query string) error {
+	var err error
+
+	// Validate, keeping the name is fine
+	if name, err = vault.validateFolderName(name); err != nil && !(err == ERR_FOLDER_EXISTS && name == old) {
+		return err
+	}
+
+	if _, err = ParseQuery(query); err != nil {
+		return err
+	}
+
+	// Update
+	if index := vault.findSmartFolder(old); index != -1 {
+		vault.checkpoint(fmt.Sprintf("edited the %s smart folder", old))
+
+		vault.SmartFolders[index] = SmartFolder{Name: name, Query: query}
+
+		// Write
+		vault.write()
+	}
+
+	return nil
+}
+
+// Deletes a smart folder by its name, the tokens are kept
+func (vault *Vault) DeleteSmartFolder(name string) {
+	if index := vault.findSmartFolder(name); index != -1 {
+		vault.checkpoint(fmt.Sprintf("deleted the %s smart folder", name))
+
+		// Remove
+		vault.SmartFolders = utils.Remove(vault.SmartFolders, index)
+
+		// Write
+		vault.write()
+	}
+}
+
+// Returns the smart folders as virtual folders with the tokens that match their query
+func (vault *Vault) SmartFolderViews() []Folder {
+	return utils.Map(vault.SmartFolders, func(folder SmartFolder) Folder {
+		return vault.VirtualFolder(folder.Name, folder.Query)
+	})
+}
+
+// Returns the index of the smart folder with the given name
+func (vault Vault) findSmartFolder(name string) int {
+	return slices.IndexFunc(vault.SmartFolders, func(folder SmartFolder) bool { return folder.Name == name })
+}
@@ -0,0 +1,86 @@
+package tlockvault
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Compares the tokens for the sort mode, where 0 keeps the manual order
+func compareTokens(mode SortMode, a, b Token) int {
+	switch mode {
+	case SortIssuer:
+		return cmp.Or(
+			strings.Compare(strings.ToLower(a.Issuer), strings.ToLower(b.Issuer)),
+			strings.Compare(strings.ToLower(a.Account), strings.ToLower(b.Account)),
+		)
+
+	case SortAccount:
+		return cmp.Or(
+			strings.Compare(strings.ToLower(a.Account), strings.ToLower(b.Account)),
+			strings.Compare(strings.ToLower(a.Issuer), strings.ToLower(b.Issuer)),
+		)
+
+	case SortMostUsed:
+		return cmp.Compare(b.CopyCount, a.CopyCount)
+
+	case SortRecentlyUsed:
+		return b.LastUsed.Compare(a.LastUsed)
+	}
+
+	return 0
+}
+
+// Returns the tokens of the folder in the order of its sort mode
+func (vault *Vault) SortedTokens(folder string) []Token {
+	index := vault.findFolder(folder)
+
+	// The folder may have been renamed or deleted
+	if index == -1 {
+		return []Token{}
+	}
+
+	// Sort a copy, the manual order is kept in the vault
+	tokens := slices.Clone(vault.Folders[index].Tokens)
+
+	slices.SortStableFunc(tokens, func(a, b Token) int {
+		return compareTokens(vault.Folders[index].SortMode, a, b)
+	})
+
+	return tokens
+}
+
+// Returns the sort mode of the folder
+func (vault *Vault) SortModeOf(folder string) SortMode {
+	if index := vault.findFolder(folder); index != -1 {
+		return vault.Folders[index].SortMode
+	}
+
+	return SortManual
+}
+
+// Sets the sort mode of the folder
+func (vault *Vault) SetSortMode(folder string, mode SortMode) {
+	if index := vault.findFolder(folder); index != -1 {
+		vault.checkpoint(fmt.Sprintf("sorted the %s folder by %s", folder, mode))
+
+		vault.Folders[index].SortMode = mode
+
+		// Write
+		vault.write()
+	}
+}
+
+// Records that the code of the token was copied
+func (vault *Vault) MarkTokenUsed(folder string, token Token) {
+	// Find the folder
+	if folder, token := vault.locateToken(folder, token); folder != -1 && token != -1 {
+		vault.Folders[folder].Tokens[token].CopyCount++
+		vault.Folders[folder].Tokens[token].LastUsed = time.Now()
+
+		// Write
+		vault.write()
+	}
+}
@@ -1,12 +1,22 @@
 package tlockvault
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"slices"
+	"strconv"
 
 	"github.com/eklairs/tlock/tlock-internal/utils"
 	"github.com/pquerna/otp"
 )
 
+// Error representing that the counter of the otpauth URI is not a number
+var ERR_INVALID_COUNTER = errors.New("Counter of the URI must be a non-negative number")
+
+// Error representing that the period of the otpauth URI is not greater than 0
+var ERR_INVALID_PERIOD = errors.New("Period of the URI must be greater than 0")
+
 // Converts `totp` or `hotp` to TokenType
 func toType(type_ string) TokenType {
 	if type_ == "hotp" {
@@ -16,28 +26,88 @@ func toType(type_ string) TokenType {
 	return TokenTypeTOTP
 }
 
-// Adds a new token to the given folder from token URI
-func (vault *Vault) AddToken(folder string, uri string) error {
+// Builds a token from the given otpauth URI
+func TokenFromURI(uri string) (Token, error) {
 	// Key
 	var key *otp.Key
 	var err error
 
 	// Generate key
-	if key, err = otp.NewKeyFromURL(uri); err == nil {
-		// Generate token
-		token := Token{
-			Type:             toType(key.Type()),
-			Issuer:           key.Issuer(),
-			Account:          key.AccountName(),
-			Secret:           key.Secret(),
-			InitialCounter:   0,
-			Period:           int(key.Period()),
-			Digits:           key.Digits().Length(),
-			HashingAlgorithm: key.Algorithm(),
-			UsageCounter:     0,
+	if key, err = otp.NewKeyFromURL(uri); err != nil {
+		return Token{}, err
+	}
+
+	// Counter of the HOTP based tokens, which the key does not parse
+	counter := 0
+
+	parsed, _ := url.Parse(uri)
+
+	if value := parsed.Query().Get("counter"); value != "" && key.Type() == "hotp" {
+		if counter, err = strconv.Atoi(value); err != nil || counter < 0 {
+			return Token{}, ERR_INVALID_COUNTER
 		}
+	}
 
-		// Add
+	// The codes of TOTP based tokens are refreshed every period
+	if key.Type() == "totp" && key.Period() == 0 {
+		return Token{}, ERR_INVALID_PERIOD
+	}
+
+	// Generate token
+	return Token{
+		Type:             toType(key.Type()),
+		Issuer:           key.Issuer(),
+		Account:          key.AccountName(),
+		Secret:           key.Secret(),
+		InitialCounter:   counter,
+		Period:           int(key.Period()),
+		Digits:           key.Digits().Length(),
+		HashingAlgorithm: key.Algorithm(),
+		UsageCounter:     0,
+	}, nil
+}
+
+// Returns the otpauth URI of the token, which can be imported by other apps
+func (token Token) URI() string {
+	type_ := "totp"
+
+	if token.Type == TokenTypeHOTP {
+		type_ = "hotp"
+	}
+
+	params := url.Values{}
+	params.Set("secret", token.Secret)
+	params.Set("algorithm", token.HashingAlgorithm.String())
+	params.Set("digits", strconv.Itoa(token.Digits))
+
+	if token.Issuer != "" {
+		params.Set("issuer", token.Issuer)
+	}
+
+	if token.Type == TokenTypeHOTP {
+		params.Set("counter", strconv.Itoa(token.InitialCounter+token.UsageCounter))
+	} else {
+		params.Set("period", strconv.Itoa(token.Period))
+	}
+
+	// Label is issuer:account, or just the account without an issuer
+	label := token.Account
+
+	if token.Issuer != "" {
+		label = fmt.Sprintf("%s:%s", token.Issuer, token.Account)
+	}
+
+	uri := url.URL{Scheme: "otpauth", Host: type_, Path: "/" + label, RawQuery: params.Encode()}
+
+	return uri.String()
+}
+
+// Adds a new token to the given folder from token URI
+func (vault *Vault) AddToken(folder string, uri string) error {
+	token, err := TokenFromURI(uri)
+
+	// Add
+	if err == nil {
 		return vault.AddTokenFromToken(folder, token)
 	}
 
@@ -49,11 +119,18 @@ func (vault *Vault) AddToken(folder string, uri string) error {
 func (vault *Vault) AddTokenFromToken(folder string, token Token) error {
 	var err error
 
+	// Find folder
+	index := vault.findFolder(folder)
+
+	if index == -1 {
+		return ERR_FOLDER_NOT_FOUND
+	}
+
 	if token.Secret, err = vault.ValidateToken(token.Secret); err == nil {
-		// Find folder and if it exists, add
-		if index := vault.findFolder(folder); index != -1 {
-			vault.Folders[index].Tokens = append(vault.Folders[index].Tokens, token)
-		}
+		vault.checkpoint(fmt.Sprintf("added %s", describeTokens([]Token{token})))
+
+		// Add
+		vault.Folders[index].Tokens = append(vault.Folders[index].Tokens, token)
 
 		// Write
 		vault.write()
@@ -70,8 +147,17 @@ func (vault *Vault) ReplaceToken(fromFolder string, token, newToken Token) error
 	if newToken.Secret, err = vault.ValidateToken(newToken.Secret); token.Secret == newToken.Secret || err == nil {
 		// Get folder index
 		if index := vault.findFolder(fromFolder); index != -1 {
+			tokenIndex := vault.findToken(index, token.Secret)
+
+			vault.checkpoint(fmt.Sprintf("edited %s", describeTokens([]Token{token})))
+
+			// Keep the usage of the token
+			newToken.CopyCount = vault.Folders[index].Tokens[tokenIndex].CopyCount
+			newToken.LastUsed = vault.Folders[index].Tokens[tokenIndex].LastUsed
+			newToken.Favorite = vault.Folders[index].Tokens[tokenIndex].Favorite
+
 			// Replace
-			vault.Folders[index].Tokens[vault.findToken(index, token.Secret)] = newToken
+			vault.Folders[index].Tokens[tokenIndex] = newToken
 
 			// Write
 			vault.write()
@@ -84,10 +170,15 @@ func (vault *Vault) ReplaceToken(fromFolder string, token, newToken Token) error
 	return err
 }
 
-// Deletes a token in the given folder
+// Deletes a token in the given folder, it is moved to the trash
 func (vault *Vault) DeleteToken(folder string, token Token) {
+	name := describeTokens([]Token{token})
+
 	// Find the folder
 	if folder, token := vault.locateToken(folder, token); folder != -1 && token != -1 {
+		vault.checkpoint(fmt.Sprintf("deleted %s", name))
+
+		vault.trashToken(folder, token)
 		vault.Folders[folder].Tokens = utils.Remove(vault.Folders[folder].Tokens, token)
 	}
 
@@ -96,12 +187,11 @@ func (vault *Vault) DeleteToken(folder string, token Token) {
 }
 
 // Move a token to the given folder
+// It is undone as a single change
 func (vault *Vault) MoveToken(token Token, fromFolder, toFolder string) {
-	// Remove from existing
-	vault.DeleteToken(fromFolder, token)
-
-	// Add to the new one
-	vault.AddTokenFromToken(toFolder, token)
+	if vault.findFolder(fromFolder) != -1 {
+		vault.MoveTokens([]Token{token}, toFolder)
+	}
 }
 
 // Move a token to the given folder
@@ -117,6 +207,8 @@ func (vault *Vault) IncreaseCounter(folder string, token Token) {
 
 // Moves the token down
 func (vault *Vault) MoveTokenDown(folder string, token Token) bool {
+	name := describeTokens([]Token{token})
+
 	// Find
 	if folder, token := vault.locateToken(folder, token); folder != -1 && token != -1 {
 		// If it is already at the bottom, skip
@@ -124,6 +216,8 @@ func (vault *Vault) MoveTokenDown(folder string, token Token) bool {
 			return false
 		}
 
+		vault.checkpoint(fmt.Sprintf("moved %s down", name))
+
 		// Swapppp
 		vault.Folders[folder].Tokens = utils.Swap(vault.Folders[folder].Tokens, token, token+1)
 
@@ -139,6 +233,8 @@ func (vault *Vault) MoveTokenDown(folder string, token Token) bool {
 
 // Moves the token up
 func (vault *Vault) MoveTokenUp(folder string, token Token) bool {
+	name := describeTokens([]Token{token})
+
 	// Find
 	if folder, token := vault.locateToken(folder, token); folder != -1 && token != -1 {
 		// If it is already at the bottom, skip
@@ -146,6 +242,8 @@ func (vault *Vault) MoveTokenUp(folder string, token Token) bool {
 			return false
 		}
 
+		vault.checkpoint(fmt.Sprintf("moved %s up", name))
+
 		// Swapppp
 		vault.Folders[folder].Tokens = utils.Swap(vault.Folders[folder].Tokens, token, token-1)
 
@@ -175,6 +273,17 @@ func (vault *Vault) tokenExists(secret string) bool {
 	return false
 }
 
+// Returns the folder in which the token is stored
+func (vault *Vault) FolderOf(token Token) Folder {
+	for i := 0; i < len(vault.Folders); i++ {
+		if vault.findToken(i, token.Secret) != -1 {
+			return vault.Folders[i]
+		}
+	}
+
+	return Folder{}
+}
+
 // Finds the index of folder as well token
 func (vault *Vault) locateToken(folder string, token Token) (int, int) {
 	// Find folder index
@@ -0,0 +1,148 @@
+package tlockvault
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/eklairs/tlock/tlock-internal/utils"
+)
+
+// Returns the name of the item, like `alice` for a token or `Work` for a folder
+func (item TrashItem) Name() string {
+	if item.Folder != nil {
+		return item.Folder.Name
+	}
+
+	if item.Token.Account == "" {
+		return "<no account name>"
+	}
+
+	return item.Token.Account
+}
+
+// Returns if the item is a deleted folder
+func (item TrashItem) IsFolder() bool {
+	return item.Folder != nil
+}
+
+// Moves the token at the given index of the folder to the trash
+// It does not remove it from the folder
+func (vault *Vault) trashToken(folder, index int) {
+	token := vault.Folders[folder].Tokens[index]
+
+	vault.Trash = append(vault.Trash, TrashItem{Token: &token, From: vault.Folders[folder].Name, DeletedAt: time.Now()})
+}
+
+// Moves the folder at the given index to the trash, along with its tokens
+// It does not remove it from the folders
+func (vault *Vault) trashFolder(index int) {
+	folder := vault.Folders[index]
+	folder.Tokens = slices.Clone(folder.Tokens)
+
+	vault.Trash = append(vault.Trash, TrashItem{Folder: &folder, From: folder.Parent, DeletedAt: time.Now()})
+}
+
+// Restores the item of the trash
+// A token is restored to the given folder, and a folder inside of the given parent, or the top level if it is empty
+func (vault *Vault) RestoreTrashItem(index int, to string) error {
+	if index < 0 || index >= len(vault.Trash) {
+		return ERR_TRASH_ITEM_NOT_FOUND
+	}
+
+	item := vault.Trash[index]
+
+	// Validate
+	if (to != "" && !vault.folderExists(to)) || (to == "" && !item.IsFolder()) {
+		return ERR_FOLDER_NOT_FOUND
+	}
+
+	if item.IsFolder() {
+		if _, err := vault.validateFolderName(item.Folder.Name); err != nil {
+			return err
+		}
+
+		// The same tokens may have been added again since
+		for _, token := range item.Folder.Tokens {
+			if vault.tokenExists(token.Secret) {
+				return ERR_TOKEN_EXISTS
+			}
+		}
+	} else if vault.tokenExists(item.Token.Secret) {
+		return ERR_TOKEN_EXISTS
+	}
+
+	vault.checkpoint(fmt.Sprintf("restored %s from the trash", item.Name()))
+
+	// Restore
+	if item.IsFolder() {
+		folder := *item.Folder
+		folder.Parent = to
+
+		vault.Folders = append(vault.Folders, folder)
+	} else {
+		folder := vault.findFolder(to)
+
+		vault.Folders[folder].Tokens = append(vault.Folders[folder].Tokens, *item.Token)
+	}
+
+	vault.Trash = utils.Remove(vault.Trash, index)
+
+	// Write
+	vault.write()
+
+	return nil
+}
+
+// Permanently deletes the item of the trash
+// It cannot be undone, so the changes of this session are forgotten as they may hold the item
+func (vault *Vault) PurgeTrashItem(index int) {
+	if index < 0 || index >= len(vault.Trash) {
+		return
+	}
+
+	vault.auditNext(vault.snapshot(""), fmt.Sprintf("permanently deleted %s", vault.Trash[index].Name()))
+
+	vault.Trash = utils.Remove(vault.Trash, index)
+	vault.forgetHistory()
+
+	// Write
+	vault.write()
+}
+
+// Permanently deletes every item of the trash
+// It cannot be undone, so the changes of this session are forgotten as they may hold the items
+func (vault *Vault) EmptyTrash() {
+	if len(vault.Trash) == 0 {
+		return
+	}
+
+	vault.auditNext(vault.snapshot(""), "emptied the trash")
+
+	vault.Trash = nil
+	vault.forgetHistory()
+
+	// Write
+	vault.write()
+}
+
+// Permanently deletes the items which have been in the trash for longer than the retention period
+// Nothing is deleted if the retention period is 0
+func (vault *Vault) PurgeExpiredTrash(retention time.Duration) {
+	if retention <= 0 {
+		return
+	}
+
+	kept := slices.DeleteFunc(slices.Clone(vault.Trash), func(item TrashItem) bool {
+		return time.Since(item.DeletedAt) > retention
+	})
+
+	// Only write if something was purged
+	if len(kept) != len(vault.Trash) {
+		vault.logAudit(fmt.Sprintf("removed %d items from the trash after %d days", len(vault.Trash)-len(kept), int(retention.Hours()/24)), nil)
+
+		vault.Trash = kept
+
+		vault.write()
+	}
+}
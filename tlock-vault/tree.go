@@ -0,0 +1,178 @@
+package tlockvault
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Folder along with its place in the tree of folders
+type FolderNode struct {
+	// Folder
+	Folder Folder
+
+	// Number of parents above the folder, 0 for the top level folders
+	Depth int
+
+	// Whether the folder has subfolders
+	HasSubfolders bool
+}
+
+// Returns the folders in the order of the tree, where every folder is followed by its subfolders
+// The subfolders of the folders in `collapsed` are skipped
+func (vault *Vault) FolderTree(collapsed map[string]bool) []FolderNode {
+	nodes := make([]FolderNode, 0, len(vault.Folders))
+	visited := make(map[string]bool)
+
+	var walk func(parent string, depth int)
+
+	walk = func(parent string, depth int) {
+		for _, folder := range vault.Folders {
+			if vault.parentOf(folder) != parent || visited[folder.Name] {
+				continue
+			}
+
+			visited[folder.Name] = true
+
+			nodes = append(nodes, FolderNode{Folder: folder, Depth: depth, HasSubfolders: vault.HasSubfolders(folder.Name)})
+
+			if !collapsed[folder.Name] {
+				walk(folder.Name, depth+1)
+			}
+		}
+	}
+
+	walk("", 0)
+
+	return nodes
+}
+
+// Adds a new folder inside of the parent folder
+func (vault *Vault) AddSubfolder(parent, name string) error {
+	if !vault.folderExists(parent) {
+		return ERR_FOLDER_NOT_FOUND
+	}
+
+	name, err := vault.validateFolderName(name)
+
+	if err == nil {
+		vault.checkpoint(fmt.Sprintf("added the %s folder inside of %s", name, parent))
+
+		// Add folder
+		vault.Folders = append(vault.Folders, Folder{Name: name, Parent: parent})
+
+		// Write
+		vault.write()
+	}
+
+	return err
+}
+
+// Moves the folder inside of the parent folder, or to the top level if the parent is empty
+func (vault *Vault) SetParent(name, parent string) error {
+	index := vault.findFolder(name)
+
+	if index == -1 || (parent != "" && !vault.folderExists(parent)) {
+		return ERR_FOLDER_NOT_FOUND
+	}
+
+	// A folder cannot be moved inside of itself
+	if parent == name || vault.isDescendant(parent, name) {
+		return ERR_FOLDER_CYCLE
+	}
+
+	if parent == "" {
+		vault.checkpoint(fmt.Sprintf("moved the %s folder to the top level", name))
+	} else {
+		vault.checkpoint(fmt.Sprintf("moved the %s folder inside of %s", name, parent))
+	}
+
+	vault.Folders[index].Parent = parent
+
+	// Write
+	vault.write()
+
+	return nil
+}
+
+// Returns the number of tokens inside of the folder and all of its subfolders
+func (vault *Vault) TokenCount(name string) int {
+	count := 0
+
+	for _, folder := range vault.Folders {
+		if folder.Name == name || vault.isDescendant(folder.Name, name) {
+			count += len(folder.Tokens)
+		}
+	}
+
+	return count
+}
+
+// Returns the path of the folder, like `Client / Production`
+func (vault *Vault) FolderPath(name string) string {
+	path := []string{name}
+
+	for parent := vault.parentNameOf(name); parent != "" && len(path) <= len(vault.Folders); parent = vault.parentNameOf(parent) {
+		path = append([]string{parent}, path...)
+	}
+
+	return strings.Join(path, " / ")
+}
+
+// Returns the name of the parent of the folder, empty if it is at the top level
+// Folders whose parent does not exist are at the top level
+func (vault *Vault) parentOf(folder Folder) string {
+	if folder.Parent == "" || !vault.folderExists(folder.Parent) {
+		return ""
+	}
+
+	return folder.Parent
+}
+
+// Returns the name of the parent of the folder with the given name
+func (vault *Vault) parentNameOf(name string) string {
+	if index := vault.findFolder(name); index != -1 {
+		return vault.parentOf(vault.Folders[index])
+	}
+
+	return ""
+}
+
+// Returns if the folder has any subfolders
+func (vault *Vault) HasSubfolders(name string) bool {
+	for _, folder := range vault.Folders {
+		if vault.parentOf(folder) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns if the folder is inside of the ancestor, at any level
+func (vault *Vault) isDescendant(name, ancestor string) bool {
+	for parent, steps := vault.parentNameOf(name), 0; parent != "" && steps < len(vault.Folders); parent, steps = vault.parentNameOf(parent), steps+1 {
+		if parent == ancestor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the index of the sibling next to the folder, in the given direction
+// Returns -1 if there is none
+func (vault *Vault) siblingOf(index, direction int) int {
+	if index == -1 {
+		return -1
+	}
+
+	parent := vault.parentOf(vault.Folders[index])
+
+	for i := index + direction; i >= 0 && i < len(vault.Folders); i += direction {
+		if vault.parentOf(vault.Folders[i]) == parent {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -1,6 +1,12 @@
 package tlockvault
 
-import "github.com/pquerna/otp"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/pquerna/otp"
+)
 
 // Token types
 const (
@@ -11,41 +17,167 @@ const (
 // Token Type
 type TokenType int
 
+// Sort modes of the tokens in a folder
+const (
+	SortManual = iota
+	SortIssuer
+	SortAccount
+	SortMostUsed
+	SortRecentlyUsed
+)
+
+// Sort mode
+type SortMode int
+
+// Names of the sort modes, in the order of their values
+var SORT_MODE_NAMES = []string{"manual", "issuer A–Z", "account A–Z", "most used", "recently used"}
+
 // Token
 type Token struct {
 	// Type
-	Type TokenType
+	Type TokenType `json:"type"`
 
 	// Issuer name
-	Issuer string
+	Issuer string `json:"issuer"`
 
 	// Account name
-	Account string
+	Account string `json:"account"`
 
 	// Secret
-	Secret string
+	Secret string `json:"secret"`
 
 	// Initial counter [only in case of HOTP based tokens]
-	InitialCounter int
+	InitialCounter int `json:"initial_counter"`
 
 	// Period [only in case of TOTP based tokens]
-	Period int
+	Period int `json:"period"`
 
 	// Digits
-	Digits int
+	Digits int `json:"digits"`
 
 	// Hasing function
-	HashingAlgorithm otp.Algorithm
+	HashingAlgorithm otp.Algorithm `json:"hashing_algorithm"`
 
 	// Usage counter [only in case of HOTP based tokens]
-	UsageCounter int
+	UsageCounter int `json:"usage_counter"`
+
+	// Number of times the code was copied
+	CopyCount int `json:"copy_count"`
+
+	// Last time the code was copied
+	LastUsed time.Time `json:"last_used"`
+
+	// Whether the token is shown in the favorites
+	Favorite bool `json:"favorite"`
+
+	// Tags, used by the queries of the smart folders
+	Tags []string `json:"tags"`
+
+	// Name of the icon chosen for the token, found from the issuer if empty
+	Icon string `json:"icon"`
 }
 
 // Folder
 type Folder struct {
 	// Name of the folder
-	Name string
+	Name string `json:"name"`
+
+	// Name of the parent folder, empty for the top level folders
+	Parent string `json:"parent"`
 
 	// Tokens
-	Tokens []Token
+	Tokens []Token `json:"tokens"`
+
+	// Order in which the tokens are shown
+	SortMode SortMode `json:"sort_mode"`
+
+	// Whether the folder is not stored in the vault, like the favorites
+	Virtual bool `json:"-"`
+
+	// Query that picks the tokens of a virtual folder
+	Query string `json:"-"`
+
+	// Whether it is the virtual folder with the favorite tokens
+	Favorites bool `json:"-"`
+}
+
+// Folder with the tokens that match its query
+type SmartFolder struct {
+	// Name of the folder
+	Name string `json:"name"`
+
+	// Query
+	Query string `json:"query"`
+}
+
+// Token or folder which was deleted
+type TrashItem struct {
+	// Deleted token, nil if a folder was deleted
+	Token *Token `json:"token,omitempty"`
+
+	// Deleted folder along with its tokens, nil if a token was deleted
+	Folder *Folder `json:"folder,omitempty"`
+
+	// Folder in which the token was, or the parent of the folder
+	From string `json:"from"`
+
+	// Time at which it was deleted
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Change to the vault, as recorded in the audit log
+type AuditEntry struct {
+	// Time at which the change was made
+	Time time.Time `json:"time"`
+
+	// Who made the change, like `alice` or `alice (cli)`
+	Actor string `json:"actor"`
+
+	// What the change did, like `deleted the Work folder`
+	Action string `json:"action"`
+
+	// Fields which were changed
+	Changes []AuditChange `json:"changes"`
+}
+
+// Field changed by an audited change, the secrets are never recorded
+type AuditChange struct {
+	// Token or folder which was changed, like `alice • GitHub (c92a23f3)`
+	Target string `json:"target"`
+
+	// Name of the field
+	Field string `json:"field"`
+
+	// Value before the change, empty if it was added
+	Old string `json:"old"`
+
+	// Value after the change, empty if it was removed
+	New string `json:"new"`
+}
+
+// Returns if the folder is a smart folder, which is virtual but not the favorites
+func (folder Folder) IsSmart() bool {
+	return folder.Virtual && !folder.Favorites
+}
+
+// Returns the name of the sort mode
+func (mode SortMode) String() string {
+	if mode < 0 || int(mode) >= len(SORT_MODE_NAMES) {
+		return SORT_MODE_NAMES[SortManual]
+	}
+
+	return SORT_MODE_NAMES[mode]
+}
+
+// Returns the sort mode after this one, wrapping around to manual
+func (mode SortMode) Next() SortMode {
+	return (mode + 1) % SortMode(len(SORT_MODE_NAMES))
+}
+
+// Returns a short identifier for the token
+// It is derived from the secret, which is unique across the vault
+func (token Token) ID() string {
+	sum := sha256.Sum256([]byte(token.Secret))
+
+	return hex.EncodeToString(sum[:4])
 }
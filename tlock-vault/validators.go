@@ -13,6 +13,18 @@ var ERR_FOLDER_EMPTY = errors.New("Folder name cannot be empty")
 // Error representing that the folder with that name already exists
 var ERR_FOLDER_EXISTS = errors.New("Folder with that name already exists")
 
+// Error representing that the folder with that name does not exist
+var ERR_FOLDER_NOT_FOUND = errors.New("Folder with that name does not exist")
+
+// Error representing that the folder name is used by a virtual folder
+var ERR_FOLDER_RESERVED = errors.New("Folder name is reserved for the favorites")
+
+// Error representing that a folder would be moved inside of itself
+var ERR_FOLDER_CYCLE = errors.New("Folder cannot be moved inside of itself or its subfolders")
+
+// Error representing that the item is not in the trash anymore
+var ERR_TRASH_ITEM_NOT_FOUND = errors.New("Item is not in the trash anymore")
+
 // Error representing that the token secret is empty
 var ERR_TOKEN_EMPTY = errors.New("Secret value cannot be empty")
 
@@ -32,8 +44,13 @@ func (vault Vault) validateFolderName(name string) (string, error) {
 		return name, ERR_FOLDER_EMPTY
 	}
 
+	// Check if the name is used by the favorites
+	if name == FAVORITES_FOLDER {
+		return name, ERR_FOLDER_RESERVED
+	}
+
 	// Check if the folder already exists
-	if vault.folderExists(name) {
+	if vault.folderExists(name) || vault.findSmartFolder(name) != -1 {
 		return name, ERR_FOLDER_EXISTS
 	}
 
@@ -1,10 +1,22 @@
 package tlockvault
 
+import "sync"
+
 // Vault securely stores all the tokens inside of the file for tlock
 type Vault struct {
 	// All the folders and their data
 	Folders []Folder
 
+	// Folders defined by a query
+	SmartFolders []SmartFolder
+
+	// Deleted tokens and folders, which can be restored
+	Trash []TrashItem
+
+	// Every change made to the vault, the latest one is the last
+	// It is only appended to
+	Audit []AuditEntry
+
 	// Path to the file
 	path string
 
@@ -12,11 +24,29 @@ type Vault struct {
 	password string
 
 	// Channel to send the data to be written
-	dataChan chan []Folder
+	dataChan chan vaultData
+
+	// Lock to prevent simultaneous writes to the file
+	fileLock *sync.Mutex
+
+	// Changes made in this session, for undo and redo
+	history *history
+
+	// State before the change which is about to be written, recorded in the audit log on the write
+	pendingAudit *vaultSnapshot
+
+	// Who makes the changes, recorded in the audit log
+	actor string
 }
 
 // Sends the data to be written to the channel
-func (vault Vault) write() {
+// The change is recorded in the audit log if it was checkpointed
+func (vault *Vault) write() {
+	if vault.pendingAudit != nil {
+		vault.logAudit(vault.pendingAudit.description, diffSnapshots(*vault.pendingAudit, vault.snapshot("")))
+		vault.pendingAudit = nil
+	}
+
 	// Clear any existing data
 	select {
 	case <-vault.dataChan:
@@ -24,7 +54,24 @@ func (vault Vault) write() {
 	}
 
 	// Send the new data to write
-	vault.dataChan <- vault.Folders
+	vault.dataChan <- vault.data()
+}
+
+// Writes the current data to the file right away
+// Useful when the program is about to exit before the worker gets a chance to write
+func (vault *Vault) Flush() error {
+	// Drop pending data, we are writing the latest one anyway
+	select {
+	case <-vault.dataChan:
+	default:
+	}
+
+	return vault.writeFile(vault.data())
+}
+
+// Returns the data to be written to the file
+func (vault Vault) data() vaultData {
+	return vaultData{Folders: vault.Folders, SmartFolders: vault.SmartFolders, Trash: vault.Trash, Audit: vault.Audit}
 }
 
 // Updates the password for the vault
@@ -32,12 +79,17 @@ func (vault *Vault) ChangePassword(password string) {
 	// Set the master password
 	vault.password = password
 
+	vault.logAudit("changed the password", nil)
+
 	// Rewrite
 	vault.write()
 }
 
 // Stuff to run after the vault is initialized
 func (vault *Vault) PostInit() {
+	// Initialize file lock
+	vault.fileLock = &sync.Mutex{}
+
 	// Start worker
 	go vault.startFileWriterWorker(vault.dataChan)
 }
@@ -4,26 +4,50 @@ import (
 	"time"
 
 	"github.com/eklairs/tlock/tlock-internal/utils"
-	"github.com/kelindar/binary"
 )
 
 // Writing to file implementation
-func (vault *Vault) startFileWriterWorker(recv chan []Folder) {
+func (vault *Vault) startFileWriterWorker(recv chan vaultData) {
 	for {
 		if data, ok := <-recv; ok {
-			// Serialize
-			serialized, _ := binary.Marshal(data)
-
-			// Encrypt
-			encrypted, _ := Encrypt(vault.password, serialized)
-
-			// Create parent dir
-			if file, err := utils.EnsureExists(vault.path); err == nil {
-				file.Write(encrypted)
-			}
+			vault.writeFile(data)
 		}
 
 		// Sleep for 1 second
 		time.Sleep(time.Second * 1)
 	}
 }
+
+// Serializes, encrypts and writes the data to the vault file
+func (vault *Vault) writeFile(data vaultData) error {
+	vault.fileLock.Lock()
+	defer vault.fileLock.Unlock()
+
+	// Serialize
+	serialized, err := encodeVault(data)
+
+	if err != nil {
+		return err
+	}
+
+	// Encrypt
+	encrypted, err := Encrypt(vault.password, serialized)
+
+	if err != nil {
+		return err
+	}
+
+	// Create parent dir
+	file, err := utils.EnsureExists(vault.path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	// Write
+	_, err = file.Write(encrypted)
+
+	return err
+}
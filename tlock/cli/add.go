@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+)
+
+// Adds a token from an otpauth URI
+func runAdd(args []string) int {
+	var flags vaultFlags
+	var uri, folder string
+
+	// Parse flags
+	set := newFlagSet("add")
+	flags.register(set)
+	set.StringVar(&uri, "uri", "", "The otpauth:// URI of the token")
+	set.StringVar(&folder, "folder", "", "Folder to add the token to")
+
+	if positional, err := parseFlags(set, args); err != nil {
+		return ExitUsage
+	} else if len(positional) != 0 {
+		return usageError(set, "add does not take any arguments")
+	}
+
+	if uri == "" || folder == "" {
+		return usageError(set, "add requires both --uri and --folder")
+	}
+
+	// Parse the uri
+	token, err := tlockvault.TokenFromURI(uri)
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Open vault
+	vault, err := flags.open()
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Add
+	if err := vault.AddTokenFromToken(folder, token); err != nil {
+		return fail(err)
+	}
+
+	// Write before exiting
+	if err := vault.Flush(); err != nil {
+		return fail(err)
+	}
+
+	// Print the id for further commands
+	fmt.Println(token.ID())
+
+	return ExitOK
+}
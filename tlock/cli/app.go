@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/eklairs/tlock/tlock-internal/password"
+)
+
+// Parses the flags of the interactive app
+// Returns nil along with the exit code if the app should not be started
+func ParseAppFlags(args []string) (*password.Sources, int) {
+	sources := password.New()
+
+	// Parse flags
+	set := newFlagSet("tlock")
+	sources.Register(set)
+
+	set.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: tlock [--password-fd FD | --password-file PATH]")
+		fmt.Fprintln(os.Stderr)
+
+		set.PrintDefaults()
+	}
+
+	if positional, err := parseFlags(set, args); err != nil {
+		return nil, ExitUsage
+	} else if len(positional) != 0 {
+		return nil, usageError(set, "unexpected arguments")
+	}
+
+	// Read the descriptor before the app takes over the terminal
+	if err := sources.ReadFD(); err != nil {
+		return nil, fail(err)
+	}
+
+	return sources, ExitOK
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Exit codes
+const (
+	ExitOK = iota
+	ExitError
+	ExitUsage
+	ExitNotFound
+)
+
+// Represents a command line sub command
+type command struct {
+	// Name of the command
+	name string
+
+	// Arguments of the command, shown in the usage
+	args string
+
+	// Short description
+	description string
+
+	// Runs the command with the given arguments and returns the exit code
+	run func(args []string) int
+}
+
+// All the available commands
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "list", args: "[--folder NAME] [--show-secrets]", description: "List the tokens in the vault", run: runList},
+		{name: "code", args: "[--folder NAME] <issuer|account|id>", description: "Print the current code of a token", run: runCode},
+		{name: "codes", args: "[--folder NAME]", description: "Print the current codes of all the tokens", run: runCodes},
+		{name: "folders", args: "", description: "List the folders in the vault", run: runFolders},
+		{name: "users", args: "", description: "List the users", run: runUsers},
+		{name: "config", args: "check", description: "Check the config and the custom themes for errors", run: runConfig},
+		{name: "add", args: "--uri URI --folder NAME", description: "Add a token from an otpauth:// URI", run: runAdd},
+		{name: "rm", args: "[--folder NAME] <issuer|account|id>", description: "Move a token to the trash", run: runRm},
+	}
+}
+
+// Returns true if the arguments ask for a command instead of the interactive app
+// Flags without a command are for the interactive app, except for help
+func IsCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	return !strings.HasPrefix(args[0], "-") || args[0] == "-h" || args[0] == "--help"
+}
+
+// Runs the command from the given arguments and returns the exit code
+func Run(args []string) int {
+	name := args[0]
+
+	// Help
+	if name == "help" || name == "-h" || name == "--help" {
+		usage()
+
+		return ExitOK
+	}
+
+	// Find and run the command
+	for _, command := range commands {
+		if command.name == name {
+			return command.run(args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "tlock: unknown command %q\n\n", name)
+	usage()
+
+	return ExitUsage
+}
+
+// Prints the usage of tlock
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: tlock [command] [flags]")
+	fmt.Fprintln(os.Stderr, "\nRun without a command to start the interactive app, which accepts --password-fd and --password-file.")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+
+	for _, command := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %-40s %s\n", command.name, command.args, command.description)
+	}
+
+	fmt.Fprintln(os.Stderr, "\nCommands using the vault accept --user NAME to choose the user whose vault is used,")
+	fmt.Fprintln(os.Stderr, "and --password-fd FD or --password-file PATH to read the password without asking for it.")
+	fmt.Fprintln(os.Stderr, "list, code, codes, folders and users accept --format text|json|ndjson.")
+}
+
+// Creates a new flag set for the command
+func newFlagSet(name string) *flag.FlagSet {
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	// Usage
+	set.Usage = func() {
+		for _, command := range commands {
+			if command.name == name {
+				fmt.Fprintf(os.Stderr, "Usage: tlock %s %s\n\n", command.name, command.args)
+			}
+		}
+
+		set.PrintDefaults()
+	}
+
+	return set
+}
+
+// Parses the flags, allowing them to be placed anywhere between the positional arguments
+// Returns the positional arguments
+func parseFlags(set *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+
+	for {
+		if err := set.Parse(args); err != nil {
+			return nil, err
+		}
+
+		// Consumed everything
+		if set.NArg() == 0 {
+			return positional, nil
+		}
+
+		// Take the positional argument and continue parsing the rest
+		positional = append(positional, set.Arg(0))
+		args = set.Args()[1:]
+	}
+}
+
+// Reports a failure and returns the exit code for it
+func fail(err error) int {
+	fmt.Fprintf(os.Stderr, "tlock: %s\n", err)
+
+	// Decide exit code
+	if errors.Is(err, ERR_NO_MATCH) || errors.Is(err, ERR_AMBIGUOUS) {
+		return ExitNotFound
+	}
+
+	return ExitError
+}
+
+// Reports a usage error and returns the exit code for it
+func usageError(set *flag.FlagSet, message string) int {
+	fmt.Fprintf(os.Stderr, "tlock: %s\n\n", message)
+	set.Usage()
+
+	return ExitUsage
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+)
+
+// Prints the current code of a token
+func runCode(args []string) int {
+	var flags vaultFlags
+	var format formatFlags
+	var folder string
+
+	// Parse flags
+	set := newFlagSet("code")
+	flags.register(set)
+	format.register(set)
+	set.StringVar(&folder, "folder", "", "Only search the tokens of this folder")
+
+	positional, err := parseFlags(set, args)
+
+	if err != nil {
+		return ExitUsage
+	}
+
+	if len(positional) != 1 {
+		return usageError(set, "code requires exactly one issuer, account or id")
+	}
+
+	if err := format.validate(); err != nil {
+		return usageError(set, err.Error())
+	}
+
+	// Open vault
+	vault, err := flags.open()
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Find the token
+	match, err := findToken(vault, folder, positional[0])
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Build the record before the counter moves on
+	code := newCodeRecord(match)
+
+	if err := useCodes(vault, match); err != nil {
+		return fail(err)
+	}
+
+	// Machine readable output
+	if format.format != FormatText {
+		if err := format.write("code", []record{code}); err != nil {
+			return fail(err)
+		}
+
+		return ExitOK
+	}
+
+	// Print
+	fmt.Println(code.Code)
+
+	return ExitOK
+}
+
+// Moves the HOTP based tokens to their next counter, as their printed codes are now used
+// Same as generating the next code from the dashboard, it is written before exiting
+func useCodes(vault *tlockvault.Vault, matches ...match) error {
+	used := false
+
+	for _, match := range matches {
+		if match.token.Type == tlockvault.TokenTypeHOTP {
+			vault.IncreaseCounter(match.folder, match.token)
+			used = true
+		}
+	}
+
+	if !used {
+		return nil
+	}
+
+	return vault.Flush()
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// Prints the current codes of all the tokens
+func runCodes(args []string) int {
+	var flags vaultFlags
+	var format formatFlags
+	var folder string
+
+	// Parse flags
+	set := newFlagSet("codes")
+	flags.register(set)
+	format.register(set)
+	set.StringVar(&folder, "folder", "", "Only print the codes of this folder")
+
+	if positional, err := parseFlags(set, args); err != nil {
+		return ExitUsage
+	} else if len(positional) != 0 {
+		return usageError(set, "codes does not take any arguments")
+	}
+
+	if err := format.validate(); err != nil {
+		return usageError(set, err.Error())
+	}
+
+	// Open vault
+	vault, err := flags.open()
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Get tokens
+	tokens, err := allTokens(vault, folder)
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Build records
+	records := make([]*codeRecord, len(tokens))
+	generic := make([]record, len(tokens))
+
+	for index, match := range tokens {
+		records[index] = newCodeRecord(match)
+		generic[index] = records[index]
+	}
+
+	if err := useCodes(vault, tokens...); err != nil {
+		return fail(err)
+	}
+
+	// Machine readable output
+	if format.format != FormatText {
+		if err := format.write("code", generic); err != nil {
+			return fail(err)
+		}
+
+		return ExitOK
+	}
+
+	// Print as a table
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tFOLDER\tISSUER\tACCOUNT\tCODE\tREMAINING")
+
+	for _, record := range records {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", record.ID, record.Folder, record.Issuer, record.Account, record.Code, remainingText(record))
+	}
+
+	writer.Flush()
+
+	return ExitOK
+}
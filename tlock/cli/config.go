@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eklairs/tlock/tlock-internal/config"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	"github.com/eklairs/tlock/tlock-internal/paths"
+)
+
+// Checks the global config, the config of the user and the custom themes
+func runConfig(args []string) int {
+	var flags vaultFlags
+
+	// Parse flags
+	set := newFlagSet("config")
+	flags.register(set)
+
+	positional, err := parseFlags(set, args)
+
+	if err != nil {
+		return ExitUsage
+	}
+
+	if len(positional) != 1 || positional[0] != "check" {
+		return usageError(set, "config requires the check subcommand")
+	}
+
+	// Find the user
+	core, err := loadCore()
+
+	if err != nil {
+		return fail(err)
+	}
+
+	user, err := flags.resolveUser(core)
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Collect problems
+	problems := make([]string, 0)
+
+	if _, err := config.LoadGlobalConfig(); err != nil {
+		problems = append(problems, strings.Split(err.Error(), "\n")...)
+	}
+
+	if _, err := config.LoadUserConfig(user.S()); err != nil {
+		problems = append(problems, strings.Split(err.Error(), "\n")...)
+	}
+
+	for _, err := range context.InitializeContext().ThemeErrors {
+		problems = append(problems, strings.Split(err.Error(), "\n")...)
+	}
+
+	// Report
+	if len(problems) != 0 {
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stderr, "tlock: %s\n", problem)
+		}
+
+		return ExitError
+	}
+
+	fmt.Printf("%s, %s and the themes in %s are valid\n", paths.GLOBAL_CONFIG, paths.UserConfigFor(user.S()), paths.THEMES_DIR)
+
+	return ExitOK
+}
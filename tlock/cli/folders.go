@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// Lists the folders in the vault
+func runFolders(args []string) int {
+	var flags vaultFlags
+	var format formatFlags
+
+	// Parse flags
+	set := newFlagSet("folders")
+	flags.register(set)
+	format.register(set)
+
+	if positional, err := parseFlags(set, args); err != nil {
+		return ExitUsage
+	} else if len(positional) != 0 {
+		return usageError(set, "folders does not take any arguments")
+	}
+
+	if err := format.validate(); err != nil {
+		return usageError(set, err.Error())
+	}
+
+	// Open vault
+	vault, err := flags.open()
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Machine readable output
+	if format.format != FormatText {
+		records := make([]record, 0, len(vault.Folders))
+
+		for _, node := range vault.FolderTree(nil) {
+			records = append(records, &folderRecord{Name: node.Folder.Name, Parent: node.Folder.Parent, Tokens: len(node.Folder.Tokens)})
+		}
+
+		if err := format.write("folder", records); err != nil {
+			return fail(err)
+		}
+
+		return ExitOK
+	}
+
+	// Print as a table
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tTOKENS")
+
+	// Subfolders are indented below their parent
+	for _, node := range vault.FolderTree(nil) {
+		fmt.Fprintf(writer, "%s%s\t%d\n", strings.Repeat("  ", node.Depth), node.Folder.Name, len(node.Folder.Tokens))
+	}
+
+	writer.Flush()
+
+	return ExitOK
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// Lists the tokens in the vault
+func runList(args []string) int {
+	var flags vaultFlags
+	var format formatFlags
+	var folder string
+	var showSecrets bool
+
+	// Parse flags
+	set := newFlagSet("list")
+	flags.register(set)
+	format.register(set)
+	set.StringVar(&folder, "folder", "", "Only list the tokens of this folder")
+	set.BoolVar(&showSecrets, "show-secrets", false, "Include the secrets of the tokens in the output")
+
+	if positional, err := parseFlags(set, args); err != nil {
+		return ExitUsage
+	} else if len(positional) != 0 {
+		return usageError(set, "list does not take any arguments")
+	}
+
+	if err := format.validate(); err != nil {
+		return usageError(set, err.Error())
+	}
+
+	// Open vault
+	vault, err := flags.open()
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Get tokens
+	tokens, err := allTokens(vault, folder)
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Build records
+	records := make([]record, len(tokens))
+
+	for index, match := range tokens {
+		records[index] = newTokenRecord(match, showSecrets)
+	}
+
+	// Machine readable output
+	if format.format != FormatText {
+		if err := format.write("token", records); err != nil {
+			return fail(err)
+		}
+
+		return ExitOK
+	}
+
+	// Print as a table
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	if showSecrets {
+		fmt.Fprintln(writer, "ID\tFOLDER\tISSUER\tACCOUNT\tTYPE\tSECRET")
+	} else {
+		fmt.Fprintln(writer, "ID\tFOLDER\tISSUER\tACCOUNT\tTYPE")
+	}
+
+	for _, match := range tokens {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s", match.token.ID(), match.folder, match.token.Issuer, match.token.Account, tokenType(match.token))
+
+		if showSecrets {
+			fmt.Fprintf(writer, "\t%s", match.token.Secret)
+		}
+
+		fmt.Fprintln(writer)
+	}
+
+	writer.Flush()
+
+	return ExitOK
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+)
+
+// Error representing that no token matched the query
+var ERR_NO_MATCH = errors.New("No token matches the query")
+
+// Error representing that more than one token matched the query
+var ERR_AMBIGUOUS = errors.New("More than one token matches the query")
+
+// Token along with the folder it is in
+type match struct {
+	// Folder name
+	folder string
+
+	// Token
+	token tlockvault.Token
+}
+
+// Returns all the tokens, optionally only from the given folder
+func allTokens(vault *tlockvault.Vault, folder string) ([]match, error) {
+	matches := make([]match, 0)
+	found := folder == ""
+
+	for _, f := range vault.Folders {
+		if folder != "" && f.Name != folder {
+			continue
+		}
+
+		found = true
+
+		for _, token := range f.Tokens {
+			matches = append(matches, match{folder: f.Name, token: token})
+		}
+	}
+
+	if !found {
+		return nil, tlockvault.ERR_FOLDER_NOT_FOUND
+	}
+
+	return matches, nil
+}
+
+// Finds the only token matching the query
+// The query is matched against the id, then the issuer and account name, and then a part of them
+func findToken(vault *tlockvault.Vault, folder, query string) (match, error) {
+	candidates, err := allTokens(vault, folder)
+
+	if err != nil {
+		return match{}, err
+	}
+
+	// Matchers in the order of their priority
+	matchers := []func(tlockvault.Token) bool{
+		func(token tlockvault.Token) bool {
+			return strings.EqualFold(token.ID(), query)
+		},
+		func(token tlockvault.Token) bool {
+			return strings.EqualFold(token.Issuer, query) || strings.EqualFold(token.Account, query)
+		},
+		func(token tlockvault.Token) bool {
+			return strings.Contains(strings.ToLower(token.Issuer), strings.ToLower(query)) ||
+				strings.Contains(strings.ToLower(token.Account), strings.ToLower(query))
+		},
+	}
+
+	for _, matcher := range matchers {
+		matches := make([]match, 0)
+
+		for _, candidate := range candidates {
+			if matcher(candidate.token) {
+				matches = append(matches, candidate)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		}
+
+		// List the matches to let the user pick the id
+		names := make([]string, len(matches))
+
+		for index, match := range matches {
+			names[index] = fmt.Sprintf("%s (%s)", describe(match.token), match.token.ID())
+		}
+
+		return match{}, fmt.Errorf("%w: %s", ERR_AMBIGUOUS, strings.Join(names, ", "))
+	}
+
+	return match{}, ERR_NO_MATCH
+}
+
+// Returns a human readable name for the token
+func describe(token tlockvault.Token) string {
+	issuer, account := token.Issuer, token.Account
+
+	if issuer == "" {
+		issuer = "<no issuer name>"
+	}
+
+	if account == "" {
+		account = "<no account name>"
+	}
+
+	return fmt.Sprintf("%s • %s", issuer, account)
+}
+
+// Returns the type of the token as string
+func tokenType(token tlockvault.Token) string {
+	if token.Type == tlockvault.TokenTypeHOTP {
+		return "HOTP"
+	}
+
+	return "TOTP"
+}
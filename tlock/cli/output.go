@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pquerna/otp"
+
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+)
+
+// Version of the JSON output schema
+// It is bumped whenever a field is removed or changes its meaning, adding fields does not bump it
+// See docs/cli-json.md for the schema
+const SCHEMA_VERSION = 1
+
+// Output formats
+const (
+	FormatText   = "text"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// Error representing that the output format is not known
+var ERR_UNKNOWN_FORMAT = errors.New("Unknown format, must be one of text, json or ndjson")
+
+// Flags for choosing the output format
+type formatFlags struct {
+	// Output format
+	format string
+}
+
+// Registers the flags to the given flag set
+func (flags *formatFlags) register(set *flag.FlagSet) {
+	set.StringVar(&flags.format, "format", FormatText, "Output format: text, json or ndjson")
+}
+
+// Validates the choosen format
+func (flags formatFlags) validate() error {
+	switch flags.format {
+	case FormatText, FormatJSON, FormatNDJSON:
+		return nil
+	}
+
+	return ERR_UNKNOWN_FORMAT
+}
+
+// Writes the records in the choosen format
+// Text format is handled by the commands themselves
+func (flags formatFlags) write(kind string, records []record) error {
+	encoder := json.NewEncoder(os.Stdout)
+
+	// Single document with all the records
+	if flags.format == FormatJSON {
+		return encoder.Encode(document{SchemaVersion: SCHEMA_VERSION, Kind: kind + "s", Items: records})
+	}
+
+	// One record per line, each carrying the schema on its own
+	for _, record := range records {
+		record.setHeader(kind)
+
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// JSON document for the `json` format
+type document struct {
+	// Version of the schema
+	SchemaVersion int `json:"schema_version"`
+
+	// Kind of the items, like `tokens`
+	Kind string `json:"kind"`
+
+	// Items
+	Items []record `json:"items"`
+}
+
+// A single record in the output
+type record interface {
+	setHeader(kind string)
+}
+
+// Header included in every `ndjson` record
+type header struct {
+	// Version of the schema
+	SchemaVersion int `json:"schema_version,omitempty"`
+
+	// Kind of the record, like `token`
+	Kind string `json:"kind,omitempty"`
+}
+
+// Sets the header
+func (header *header) setHeader(kind string) {
+	header.SchemaVersion = SCHEMA_VERSION
+	header.Kind = kind
+}
+
+// Folder record
+type folderRecord struct {
+	header
+
+	// Name of the folder
+	Name string `json:"name"`
+
+	// Name of the parent folder, empty for the top level folders
+	Parent string `json:"parent"`
+
+	// Number of tokens in the folder
+	Tokens int `json:"tokens"`
+}
+
+// Token record
+type tokenRecord struct {
+	header
+
+	// Token ID
+	ID string `json:"id"`
+
+	// Folder the token is in
+	Folder string `json:"folder"`
+
+	// Issuer name
+	Issuer string `json:"issuer"`
+
+	// Account name
+	Account string `json:"account"`
+
+	// `totp` or `hotp`
+	Type string `json:"type"`
+
+	// Hashing algorithm, like `SHA1`
+	Algorithm string `json:"algorithm"`
+
+	// Number of digits
+	Digits int `json:"digits"`
+
+	// Period in seconds, only for totp tokens
+	Period int `json:"period,omitempty"`
+
+	// Current counter, only for hotp tokens
+	Counter *int `json:"counter,omitempty"`
+
+	// Secret, only with --show-secrets
+	Secret string `json:"secret,omitempty"`
+}
+
+// Code record
+type codeRecord struct {
+	header
+
+	// Token ID
+	ID string `json:"id"`
+
+	// Folder the token is in
+	Folder string `json:"folder"`
+
+	// Issuer name
+	Issuer string `json:"issuer"`
+
+	// Account name
+	Account string `json:"account"`
+
+	// Current code
+	Code string `json:"code"`
+
+	// Seconds before the code changes, only for totp tokens
+	RemainingSeconds *int `json:"remaining_seconds,omitempty"`
+}
+
+// User record
+type userRecord struct {
+	header
+
+	// Username
+	Name string `json:"name"`
+}
+
+// Builds the token record
+func newTokenRecord(match match, showSecret bool) *tokenRecord {
+	token := match.token
+
+	record := tokenRecord{
+		ID:        token.ID(),
+		Folder:    match.folder,
+		Issuer:    token.Issuer,
+		Account:   token.Account,
+		Type:      strings.ToLower(tokenType(token)),
+		Algorithm: algorithmName(token.HashingAlgorithm),
+		Digits:    token.Digits,
+	}
+
+	if token.Type == tlockvault.TokenTypeHOTP {
+		counter := token.InitialCounter + token.UsageCounter
+		record.Counter = &counter
+	} else {
+		record.Period = token.Period
+	}
+
+	if showSecret {
+		record.Secret = token.Secret
+	}
+
+	return &record
+}
+
+// Builds the code record
+func newCodeRecord(match match) *codeRecord {
+	record := codeRecord{
+		ID:      match.token.ID(),
+		Folder:  match.folder,
+		Issuer:  match.token.Issuer,
+		Account: match.token.Account,
+		Code:    match.token.CurrentCode(),
+	}
+
+	if match.token.Type == tlockvault.TokenTypeTOTP {
+		remaining := match.token.RemainingTime()
+		record.RemainingSeconds = &remaining
+	}
+
+	return &record
+}
+
+// Returns the name of the hashing algorithm
+func algorithmName(algorithm otp.Algorithm) string {
+	switch algorithm {
+	case otp.AlgorithmSHA256:
+		return "SHA256"
+	case otp.AlgorithmSHA512:
+		return "SHA512"
+	case otp.AlgorithmMD5:
+		return "MD5"
+	}
+
+	return "SHA1"
+}
+
+// Prints the remaining time for the text format
+func remainingText(record *codeRecord) string {
+	if record.RemainingSeconds == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%ds", *record.RemainingSeconds)
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+)
+
+// Moves a token to the trash
+func runRm(args []string) int {
+	var flags vaultFlags
+	var folder string
+
+	// Parse flags
+	set := newFlagSet("rm")
+	flags.register(set)
+	set.StringVar(&folder, "folder", "", "Only search the tokens of this folder")
+
+	positional, err := parseFlags(set, args)
+
+	if err != nil {
+		return ExitUsage
+	}
+
+	if len(positional) != 1 {
+		return usageError(set, "rm requires exactly one issuer, account or id")
+	}
+
+	// Open vault
+	vault, err := flags.open()
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Find the token
+	match, err := findToken(vault, folder, positional[0])
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Delete
+	vault.DeleteToken(match.folder, match.token)
+
+	// Write before exiting
+	if err := vault.Flush(); err != nil {
+		return fail(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Moved %s (%s) from %s to the trash\n", describe(match.token), match.token.ID(), match.folder)
+
+	return ExitOK
+}
@@ -0,0 +1,51 @@
+package cli
+
+import "fmt"
+
+// Lists the users
+func runUsers(args []string) int {
+	var format formatFlags
+
+	// Parse flags
+	set := newFlagSet("users")
+	format.register(set)
+
+	if positional, err := parseFlags(set, args); err != nil {
+		return ExitUsage
+	} else if len(positional) != 0 {
+		return usageError(set, "users does not take any arguments")
+	}
+
+	if err := format.validate(); err != nil {
+		return usageError(set, err.Error())
+	}
+
+	// Initialize core
+	core, err := loadCore()
+
+	if err != nil {
+		return fail(err)
+	}
+
+	// Machine readable output
+	if format.format != FormatText {
+		records := make([]record, len(core.Users))
+
+		for index, user := range core.Users {
+			records[index] = &userRecord{Name: user.S()}
+		}
+
+		if err := format.write("user", records); err != nil {
+			return fail(err)
+		}
+
+		return ExitOK
+	}
+
+	// Print
+	for _, user := range core.Users {
+		fmt.Println(user.S())
+	}
+
+	return ExitOK
+}
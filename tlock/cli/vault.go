@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+
+	tlockcore "github.com/eklairs/tlock/tlock-core"
+	"github.com/eklairs/tlock/tlock-internal/password"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	"golang.org/x/term"
+)
+
+// Error representing that there are no users
+var ERR_NO_USERS = errors.New("No users found, run tlock to create one")
+
+// Error representing that the user could not be decided
+var ERR_USER_REQUIRED = errors.New("Multiple users found, choose one with --user")
+
+// Error representing that the user does not exist
+var ERR_USER_NOT_FOUND = errors.New("User with that name does not exist")
+
+// Error representing that the password is required but cannot be prompted
+var ERR_PASSWORD_REQUIRED = errors.New("Vault is protected with a password, but there is no terminal to ask for it, use --password-fd, --password-file, TLOCK_PASSWORD or password_command")
+
+// Flags shared by every command which uses the vault
+type vaultFlags struct {
+	// User whose vault to use
+	user string
+
+	// Non-interactive password sources
+	sources password.Sources
+}
+
+// Registers the flags to the given flag set
+func (flags *vaultFlags) register(set *flag.FlagSet) {
+	set.StringVar(&flags.user, "user", "", "User whose vault to use (default: the only user, or the current system user)")
+	flags.sources.Register(set)
+}
+
+// Decides the user to use
+func (flags vaultFlags) resolveUser(core *tlockcore.TLockCore) (tlockcore.User, error) {
+	// User specified
+	if flags.user != "" {
+		if !core.Exists(flags.user) {
+			return "", ERR_USER_NOT_FOUND
+		}
+
+		return tlockcore.User(flags.user), nil
+	}
+
+	switch len(core.Users) {
+	case 0:
+		return "", ERR_NO_USERS
+	case 1:
+		return core.Users[0], nil
+	}
+
+	// Try the current system user
+	if current, err := user.Current(); err == nil && core.Exists(current.Username) {
+		return tlockcore.User(current.Username), nil
+	}
+
+	return "", ERR_USER_REQUIRED
+}
+
+// Initializes the core, where a missing list of users means that there are no users yet
+func loadCore() (*tlockcore.TLockCore, error) {
+	core, err := tlockcore.New()
+
+	if errors.Is(err, os.ErrNotExist) {
+		return core, nil
+	}
+
+	return core, err
+}
+
+// Opens the vault for the user
+func (flags vaultFlags) open() (*tlockvault.Vault, error) {
+	// Initialize core
+	core, err := loadCore()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the user
+	user, err := flags.resolveUser(core)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to unlock without asking
+	vault, err := flags.sources.Unlock(user)
+
+	if errors.Is(err, password.ERR_NO_SOURCE) {
+		// Ask for the password
+		pass, promptErr := promptPassword(user)
+
+		if promptErr != nil {
+			return nil, promptErr
+		}
+
+		vault, err = tlockvault.Load(user.Vault(), pass)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Changes from the cli are recorded as such in the audit log
+	vault.SetActor(fmt.Sprintf("%s (cli)", user.S()))
+
+	return vault, nil
+}
+
+// Prompts for the password of the user on the terminal
+func promptPassword(user tlockcore.User) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", ERR_PASSWORD_REQUIRED
+	}
+
+	// Prompt on stderr to keep stdout clean for the output
+	fmt.Fprintf(os.Stderr, "Password for %s: ", user.S())
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+
+	return string(password), err
+}
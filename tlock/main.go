@@ -1,19 +1,36 @@
 package main
 
 import (
+	"os"
+
 	"github.com/muesli/termenv"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/eklairs/tlock/tlock-internal/clipboard"
 	"github.com/eklairs/tlock/tlock-internal/context"
+	"github.com/eklairs/tlock/tlock/cli"
 	tlockmodels "github.com/eklairs/tlock/tlock/models"
 	tlockstyles "github.com/eklairs/tlock/tlock/styles"
 )
 
 // TLock go brrr
 func main() {
+	// Run the command instead if it is asked for
+	if args := os.Args[1:]; cli.IsCommand(args) {
+		os.Exit(cli.Run(args))
+	}
+
+	// Parse flags of the app
+	sources, code := cli.ParseAppFlags(os.Args[1:])
+
+	if sources == nil {
+		os.Exit(code)
+	}
+
 	// Initialize context
 	context := context.InitializeContext()
+	context.Password = sources
 	background := termenv.RGBColor(context.GetCurrentTheme().Background)
 
 	// Initialize styles
@@ -26,4 +43,9 @@ func main() {
 	if _, err := program.Run(); err != nil {
 
 	}
+
+	// Do not leave the copied code behind, unless it is never cleared
+	if context.Config.ClipboardTimeout() > 0 {
+		clipboard.ClearPending()
+	}
 }
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+	"golang.org/x/term"
+)
+
+var auditLogAscii = `
+▄▀█ █ █ █▀▄ █ ▀█▀
+█▀█ █▄█ █▄▀ █  █ `
+
+// Renders a single change of an entry
+func renderAuditChange(change tlockvault.AuditChange) string {
+	var text string
+
+	switch {
+	case change.Old == "":
+		text = fmt.Sprintf("%s: added %s", change.Field, change.New)
+	case change.New == "":
+		text = fmt.Sprintf("%s: removed %s", change.Field, change.Old)
+	default:
+		text = fmt.Sprintf("%s: %s → %s", change.Field, change.Old, change.New)
+	}
+
+	return tlockstyles.Dimmed(fmt.Sprintf("  %s · %s", change.Target, text))
+}
+
+// Builds the audit log, newest entries first
+func buildAuditLog(vault *tlockvault.Vault) string {
+	items := make([]string, 0)
+
+	for index := len(vault.Audit) - 1; index >= 0; index-- {
+		entry := vault.Audit[index]
+		header := fmt.Sprintf("%s · %s", entry.Time.Local().Format("2 January, 2006 at 15:04"), entry.Actor)
+
+		items = append(items, tlockstyles.Dimmed(header), tlockstyles.Title(entry.Action))
+
+		for _, change := range entry.Changes {
+			items = append(items, renderAuditChange(change))
+		}
+
+		items = append(items, "")
+	}
+
+	if len(items) == 0 {
+		items = append(items, tlockstyles.Dimmed("No changes have been made to the vault yet"))
+	}
+
+	return lipgloss.NewStyle().Width(65).Render(lipgloss.JoinVertical(lipgloss.Left, items...))
+}
+
+// Audit log screen
+type AuditLogScreen struct {
+	viewport viewport.Model
+
+	// Context
+	context *context.Context
+}
+
+// Initializes a new instance of the audit log screen
+func InitializeAuditLogScreen(user string, vault *tlockvault.Vault, context *context.Context) AuditLogScreen {
+	_, height, _ := term.GetSize(int(os.Stdout.Fd()))
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		tlockstyles.Title(auditLogAscii), "",
+		tlockstyles.Dimmed(fmt.Sprintf("Changes made to the vault of %s", user)), "",
+		buildAuditLog(vault),
+	)
+
+	viewport := viewport.New(65, height)
+	viewport.SetContent(content)
+
+	return AuditLogScreen{
+		viewport: viewport,
+		context:  context,
+	}
+}
+
+// Init
+func (screen AuditLogScreen) Init() tea.Cmd {
+	return screen.viewport.Init()
+}
+
+// Update
+func (screen AuditLogScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msgType, screen.context.Config.Navigation.GoBack.Binding):
+			manager.PopScreen()
+		}
+	case tea.WindowSizeMsg:
+		screen.viewport.Height = msgType.Height
+	}
+
+	// Update viewport
+	screen.viewport, _ = screen.viewport.Update(msg)
+
+	return screen, nil
+}
+
+// View
+func (screen AuditLogScreen) View() string {
+	return screen.viewport.View()
+}
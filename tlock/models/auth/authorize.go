@@ -43,16 +43,7 @@ func (k enterPassKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Enter pass keys
-var enterPassKeys = enterPassKeyMap{
-	Login: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "login"),
-	),
-	Back: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var enterPassKeys enterPassKeyMap
 
 // Next function
 type NextFunc = func(string, *tlockvault.Vault, *context.Context) modelmanager.Screen
@@ -88,6 +79,12 @@ func InitializeEnterPassScreen(context *context.Context, user tlockcore.User, ne
 
 // Initializes enter pass screen with custom title and desc
 func InitializeEnterPassScreenCustomOpts(context *context.Context, user tlockcore.User, next NextFunc, ascii, desc string) EnterPassScreen {
+	// Initialize keys
+	enterPassKeys = enterPassKeyMap{
+		Login: context.Config.Navigation.Confirm.WithHelp("login"),
+		Back:  context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	// Password input
 	passwordInput := components.InitializeInputBox("Your password goes here...")
 	passwordInput.EchoCharacter = constants.CHAR_ECHO
@@ -40,16 +40,7 @@ func (k changePasswordKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var changePasswordKeys = changePasswordKeyMap{
-	Change: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "change password"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var changePasswordKeys changePasswordKeyMap
 
 // Change password user screen
 type ChangePasswordScreen struct {
@@ -68,6 +59,12 @@ type ChangePasswordScreen struct {
 
 // Initializes a new instance of the create user screen
 func InitializeChangePasswordScreen(context *context.Context, vault *tlockvault.Vault, user string) ChangePasswordScreen {
+	// Initialize keys
+	changePasswordKeys = changePasswordKeyMap{
+		Change: context.Config.Navigation.Confirm.WithHelp("change password"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	// Input box for password
 	newPassword := components.InitializeInputBox("Your new password goes here...")
 	newPassword.EchoMode = textinput.EchoPassword
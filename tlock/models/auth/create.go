@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/config"
 	"github.com/eklairs/tlock/tlock-internal/constants"
 	"github.com/eklairs/tlock/tlock-internal/context"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
@@ -44,20 +45,7 @@ func (k createUserKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var createUserKeys = createUserKeyMap{
-	Tab: key.NewBinding(
-		key.WithKeys("tab", "shift+tab"),
-		key.WithHelp("tab/shift+tab", "switch input"),
-	),
-	Create: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "create"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var createUserKeys createUserKeyMap
 
 // Create user screen
 type CreateUserScreen struct {
@@ -79,6 +67,13 @@ type CreateUserScreen struct {
 
 // Initializes a new instance of the create user screen
 func InitializeCreateUserScreen(context *context.Context) CreateUserScreen {
+	// Initialize keys
+	createUserKeys = createUserKeyMap{
+		Tab:    config.Combine("switch input", context.Config.Navigation.NextInput, context.Config.Navigation.PreviousInput),
+		Create: context.Config.Navigation.Confirm.WithHelp("create"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	// Placeholder
 	placeholder := "Your username goes here..."
 
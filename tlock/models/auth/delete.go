@@ -36,16 +36,7 @@ func (k deleteUserKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var deleteUserKeys = deleteUserKeyMap{
-	Delete: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "delete user"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var deleteUserKeys deleteUserKeyMap
 
 // Delete user screen
 type DeleteUserScreen struct {
@@ -57,6 +48,12 @@ type DeleteUserScreen struct {
 }
 
 func InitializeDeleteUserScreen(user string, context *context.Context) modelmanager.Screen {
+	// Initialize keys
+	deleteUserKeys = deleteUserKeyMap{
+		Delete: context.Config.Navigation.Confirm.WithHelp("delete user"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	return DeleteUserScreen{
 		User:    user,
 		Context: context,
@@ -36,16 +36,7 @@ func (k editUsernameKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var editUsernameKeys = changePasswordKeyMap{
-	Change: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "change username"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var editUsernameKeys changePasswordKeyMap
 
 // Edit username screen
 type EditUsernameScreen struct {
@@ -64,6 +55,12 @@ type EditUsernameScreen struct {
 
 // Initialize
 func InitializeEditUsernameScreen(user string, context *context.Context) modelmanager.Screen {
+	// Initialize keys
+	editUsernameKeys = changePasswordKeyMap{
+		Change: context.Config.Navigation.Confirm.WithHelp("change username"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	newUsername := components.InitializeInputBox("Your new name goes here...")
 	newUsername.Focus()
 
@@ -42,24 +42,10 @@ func (k userOptionsKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var userOptionsKeys = userOptionsKeyMap{
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "move up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "move down"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "choose option"),
-	),
-	Esc: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var userOptionsKeys userOptionsKeyMap
+
+// Options
+var userOptions = []string{"Edit username", "Change password", "Audit log", "Delete"}
 
 // User options screen
 type UserOptionsScreen struct {
@@ -78,6 +64,17 @@ type UserOptionsScreen struct {
 
 // Initializes user options screen
 func InitializeUserOptionsScreen(user string, vault *tlockvault.Vault, context *context.Context) modelmanager.Screen {
+	// Initialize keys
+	userOptionsKeys = userOptionsKeyMap{
+		Up:    context.Config.Navigation.Up.WithHelp("move up"),
+		Down:  context.Config.Navigation.Down.WithHelp("move down"),
+		Enter: context.Config.Navigation.Confirm.WithHelp("choose option"),
+		Esc:   context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
+	// Changes are recorded in the audit log as made by the user
+	vault.SetActor(user)
+
 	return UserOptionsScreen{
 		context: context,
 		user:    user,
@@ -99,7 +96,7 @@ func (screen UserOptionsScreen) Update(msg tea.Msg, manager *modelmanager.ModelM
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msgType, userOptionsKeys.Down):
-			if screen.focused != 2 {
+			if screen.focused != len(userOptions)-1 {
 				screen.focused += 1
 			}
 
@@ -120,6 +117,9 @@ func (screen UserOptionsScreen) Update(msg tea.Msg, manager *modelmanager.ModelM
 				cmd = append(cmd, manager.PushScreen(InitializeChangePasswordScreen(screen.context, screen.vault, screen.user)))
 
 			case 2:
+				cmd = append(cmd, manager.PushScreen(InitializeAuditLogScreen(screen.user, screen.vault, screen.context)))
+
+			case 3:
 				cmd = append(cmd, manager.PushScreen(InitializeDeleteUserScreen(screen.user, screen.context)))
 			}
 		}
@@ -151,11 +151,8 @@ func (screen UserOptionsScreen) View() string {
 		tlockstyles.Dimmed(fmt.Sprintf("Select an option for %s", screen.user)), "",
 	}
 
-	// Options
-	options := []string{"Edit username", "Change password", "Delete"}
-
 	// Render!
-	for index, option := range options {
+	for index, option := range userOptions {
 		// Decide the renderer based on focused index
 		renderer := components.ListItemInactive
 
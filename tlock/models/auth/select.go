@@ -1,8 +1,10 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -12,6 +14,7 @@ import (
 	"github.com/eklairs/tlock/tlock-internal/components"
 	"github.com/eklairs/tlock/tlock-internal/context"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	"github.com/eklairs/tlock/tlock-internal/password"
 	"github.com/eklairs/tlock/tlock-internal/utils"
 	"github.com/eklairs/tlock/tlock/models/dashboard"
 
@@ -75,16 +78,17 @@ func (d selectUserDelegate) Render(w io.Writer, m list.Model, index int, listIte
 
 // Select user key map
 type selectUserKeyMap struct {
-	Up      key.Binding
-	Down    key.Binding
-	Enter   key.Binding
-	New     key.Binding
-	Options key.Binding
+	Up          key.Binding
+	Down        key.Binding
+	Enter       key.Binding
+	New         key.Binding
+	Options     key.Binding
+	ChangeTheme key.Binding
 }
 
 // ShortHelp()
 func (k selectUserKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.New, k.Options, k.Enter}
+	return []key.Binding{k.Up, k.Down, k.New, k.Options, k.ChangeTheme, k.Enter}
 }
 
 // LongHelp()
@@ -93,32 +97,27 @@ func (k selectUserKeyMap) FullHelp() [][]key.Binding {
 		{k.Up},
 		{k.Down},
 		{k.New},
+		{k.ChangeTheme},
 		{k.Enter},
 	}
 }
 
 // Keys
-var selectUserKeys = selectUserKeyMap{
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "move up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "move down"),
-	),
-	New: key.NewBinding(
-		key.WithKeys("c"),
-		key.WithHelp("c", "new user"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "login as"),
-	),
-	Options: key.NewBinding(
-		key.WithKeys("o"),
-		key.WithHelp("o", "user options"),
-	),
+var selectUserKeys selectUserKeyMap
+
+// Sent when the vault of a user was tried to be unlocked without asking for the password
+type unlockedMsg struct {
+	// User
+	user tlockcore.User
+
+	// Vault, nil if it could not be unlocked
+	vault *tlockvault.Vault
+
+	// Error from the password sources
+	err error
+
+	// Whether to open the user options instead of logging in
+	options bool
 }
 
 // Select user
@@ -128,23 +127,41 @@ type SelectUserScreen struct {
 
 	// List view
 	listview list.Model
+
+	// Whether the vault of the focused user is being unlocked
+	unlocking bool
 }
 
 // New instance of select user
 func InitializeSelectUserScreen(context *context.Context) SelectUserScreen {
+	// Initialize keys
+	selectUserKeys = selectUserKeyMap{
+		Up:          context.Config.Navigation.Up.WithHelp("move up"),
+		Down:        context.Config.Navigation.Down.WithHelp("move down"),
+		New:         context.Config.Auth.NewUser.WithHelp("new user"),
+		Enter:       context.Config.Navigation.Confirm.WithHelp("login as"),
+		Options:     context.Config.Auth.UserOptions.WithHelp("user options"),
+		ChangeTheme: context.Config.Auth.ChangeTheme.WithHelp("global theme"),
+	}
+
 	// Renderable list of users
 	usersList := utils.Map(context.Core.Users, func(user tlockcore.User) list.Item { return selectUserListItem(user) })
 
+	// Initialize listview
+	listview := components.ListViewSimple(usersList, selectUserDelegate{}, 65, min(12, len(usersList)*3))
+	listview.KeyMap.CursorUp = selectUserKeys.Up
+	listview.KeyMap.CursorDown = selectUserKeys.Down
+
 	// Return instance
 	return SelectUserScreen{
 		context:  context,
-		listview: components.ListViewSimple(usersList, selectUserDelegate{}, 65, min(12, len(usersList)*3)),
+		listview: listview,
 	}
 }
 
 // Init
 func (screen SelectUserScreen) Init() tea.Cmd {
-	return nil
+	return screen.applyFocusedTheme()
 }
 
 // Update
@@ -162,44 +179,78 @@ func (screen SelectUserScreen) Update(msg tea.Msg, manager *modelmanager.ModelMa
 		case key.Matches(msgType, selectUserKeys.New):
 			cmds = append(cmds, manager.PushScreen(InitializeCreateUserScreen(screen.context)))
 
+		// Global theme, as no one is logged in yet
+		case key.Matches(msgType, selectUserKeys.ChangeTheme):
+			cmds = append(cmds, manager.PushScreen(dashboard.InitializeThemesScreen(screen.context)))
+
 			// User options
 		case key.Matches(msgType, selectUserKeys.Options):
-			// Try to unlock vault
-			focused, vault := screen.tryUnlock()
-
-			// If the vault is protected, ask for password
-			if vault == nil {
-				// Screen to go to
-				next := InitializeEnterPassScreenCustomOpts(screen.context, tlockcore.User(focused), InitializeUserOptionsScreen, sudoAscii, "Enter password for %s to see user options")
-
-				// Push
-				cmds = append(cmds, manager.PushScreen(next))
-			} else {
-				cmds = append(cmds, manager.PushScreen(InitializeUserOptionsScreen(focused.S(), vault, screen.context)))
+			if !screen.unlocking {
+				screen.unlocking = true
+				cmds = append(cmds, screen.tryUnlock(true))
 			}
 
 		case key.Matches(msgType, selectUserKeys.Enter):
-			// Try to unlock vault
-			focused, vault := screen.tryUnlock()
-
-			if vault == nil {
-				// It is encrypted with a password, require password
-				cmds = append(cmds, manager.PushScreen(InitializeEnterPassScreen(screen.context, focused, dashboard.InitializeDashboardScreen)))
-			} else {
-				// YAY!
-				cmds = append(cmds, manager.PushScreen(dashboard.InitializeDashboardScreen(focused.S(), vault, screen.context)))
+			if !screen.unlocking {
+				screen.unlocking = true
+				cmds = append(cmds, screen.tryUnlock(false))
 			}
 		}
 
+	case unlockedMsg:
+		screen.unlocking = false
+
+		// No source is configured, the password will be asked
+		var err *error
+
+		if msgType.err != nil && !errors.Is(msgType.err, password.ERR_NO_SOURCE) {
+			err = &msgType.err
+		}
+
+		switch {
+		case msgType.options && msgType.vault == nil:
+			// Screen to go to
+			next := InitializeEnterPassScreenCustomOpts(screen.context, msgType.user, InitializeUserOptionsScreen, sudoAscii, "Enter password for %s to see user options")
+			next.errorMessage = err
+
+			// Push
+			cmds = append(cmds, manager.PushScreen(next))
+
+		case msgType.options:
+			cmds = append(cmds, manager.PushScreen(InitializeUserOptionsScreen(msgType.user.S(), msgType.vault, screen.context)))
+
+		case msgType.vault == nil:
+			// It is encrypted with a password, require password
+			next := InitializeEnterPassScreen(screen.context, msgType.user, dashboard.InitializeDashboardScreen)
+			next.errorMessage = err
+
+			cmds = append(cmds, manager.PushScreen(next))
+
+		default:
+			// YAY!
+			cmds = append(cmds, manager.PushScreen(dashboard.InitializeDashboardScreen(msgType.user.S(), msgType.vault, screen.context)))
+		}
+
 	case modelmanager.ScreenRefocusedMsg:
 		// Update items
 		screen.listview.SetItems(utils.Map(screen.context.Core.Users, func(user tlockcore.User) list.Item { return selectUserListItem(user) }))
+
+		// The focused user could have been renamed or deleted
+		cmds = append(cmds, screen.applyFocusedTheme())
 	}
 
+	// Save the previous position before updating
+	previousIndex := screen.listview.Index()
+
 	// Update listview
 	screen.listview, cmd = screen.listview.Update(msg)
 	cmds = append(cmds, cmd)
 
+	// Preview the theme of the newly focused user
+	if previousIndex != screen.listview.Index() {
+		cmds = append(cmds, screen.applyFocusedTheme())
+	}
+
 	// Return
 	return screen, tea.Batch(cmds...)
 }
@@ -213,7 +264,22 @@ func (screen SelectUserScreen) View() string {
 	items := []string{
 		tlockstyles.Title(selectUserAscii), "",
 		tlockstyles.Dimmed("Select a user to login as"), "",
-		screen.listview.View(), "",
+	}
+
+	// Show which values of the global config could not be used
+	if screen.context.ConfigError != nil {
+		for _, line := range strings.Split(screen.context.ConfigError.Error(), "\n") {
+			items = append(items, tlockstyles.Styles.Error.Render(line))
+		}
+
+		items = append(items, "")
+	}
+
+	items = append(items, screen.listview.View(), "")
+
+	// The password command is still running
+	if screen.unlocking {
+		items = append(items, tlockstyles.Dimmed("Unlocking the vault..."), "")
 	}
 
 	// Add paginator
@@ -231,14 +297,25 @@ func (screen SelectUserScreen) View() string {
 	)
 }
 
-// Tries to unlock the vault for the focused user
-func (screen SelectUserScreen) tryUnlock() (tlockcore.User, *tlockvault.Vault) {
+// Applies the theme of the focused user
+func (screen SelectUserScreen) applyFocusedTheme() tea.Cmd {
+	focused, ok := screen.listview.SelectedItem().(selectUserListItem)
+
+	if !ok {
+		return nil
+	}
+
+	return tlockstyles.ApplyTheme(screen.context.ThemeFor(tlockcore.User(focused).S()))
+}
+
+// Tries to unlock the vault for the focused user without asking for the password
+// The result is sent as unlockedMsg, as the password command may take a while
+func (screen SelectUserScreen) tryUnlock(options bool) tea.Cmd {
 	// Get focused
 	focused := tlockcore.User(screen.listview.SelectedItem().(selectUserListItem))
 
-	// Try to decrypt user with empty password
-	vault, _ := tlockvault.Load(focused.Vault(), "")
-
-	// Return
-	return focused, vault
+	// Try to decrypt user with empty password, or the password from the sources
+	return screen.context.Password.UnlockCmd(focused, func(vault *tlockvault.Vault, err error) tea.Msg {
+		return unlockedMsg{user: focused, vault: vault, err: err, options: options}
+	})
 }
@@ -1,6 +1,7 @@
 package dashboard
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -9,10 +10,12 @@ import (
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
 	"github.com/eklairs/tlock/tlock/models/dashboard/folders"
 	"github.com/eklairs/tlock/tlock/models/dashboard/tokens"
+	"github.com/eklairs/tlock/tlock/models/dashboard/trash"
 	tlockstyles "github.com/eklairs/tlock/tlock/styles"
 	"golang.org/x/term"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/eklairs/tlock/tlock-internal/clipboard"
 	"github.com/eklairs/tlock/tlock-internal/components"
 	"github.com/eklairs/tlock/tlock-internal/config"
 	"github.com/eklairs/tlock/tlock-internal/context"
@@ -32,11 +35,14 @@ type dashboardKeyMap struct {
 	Help        key.Binding
 	Add         key.Binding
 	ChangeTheme key.Binding
+	Undo        key.Binding
+	Redo        key.Binding
+	Trash       key.Binding
 }
 
 // ShortHelp()
 func (k dashboardKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Help, k.Add, k.ChangeTheme}
+	return []key.Binding{k.Help, k.Add, k.ChangeTheme, k.Undo, k.Trash}
 }
 
 // FullHelp()
@@ -45,6 +51,9 @@ func (k dashboardKeyMap) FullHelp() [][]key.Binding {
 		{k.Help},
 		{k.Add},
 		{k.ChangeTheme},
+		{k.Undo},
+		{k.Redo},
+		{k.Trash},
 	}
 }
 
@@ -69,49 +78,142 @@ type DashboardScreen struct {
 	statusbar components.StatusBar
 }
 
-// Initializes a new instance of dashboard screen
-func InitializeDashboardScreen(username string, vault *tlockvault.Vault, context *context.Context) modelmanager.Screen {
-	// Load keybindings for the user
-	context.Config = config.LoadUserConfig(username)
-
-	// Initialize dashboard keymap
-	dashboardKeys = dashboardKeyMap{
+// Builds the dashboard keys from the config
+func buildDashboardKeys(context *context.Context) dashboardKeyMap {
+	return dashboardKeyMap{
 		Help: key.NewBinding(
-			key.WithKeys("?"),
-			key.WithHelp("?", "help menu"),
+			key.WithKeys(context.Config.Dashboard.Help.Keys()...),
+			key.WithHelp(strings.Join(context.Config.Dashboard.Help.Keys(), "/"), "help menu"),
 		),
 		Add: key.NewBinding(
 			key.WithKeys(context.Config.Folder.Add.Keys()...),
 			key.WithHelp(strings.Join(context.Config.Folder.Add.Keys(), "/"), "add folder"),
 		),
 		ChangeTheme: key.NewBinding(
-			key.WithKeys("ctrl+t"),
-			key.WithHelp("ctrl+t", "change theme"),
+			key.WithKeys(context.Config.Dashboard.ChangeTheme.Keys()...),
+			key.WithHelp(strings.Join(context.Config.Dashboard.ChangeTheme.Keys(), "/"), "change theme"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys(context.Config.Dashboard.Undo.Keys()...),
+			key.WithHelp(strings.Join(context.Config.Dashboard.Undo.Keys(), "/"), "undo"),
+		),
+		Redo: key.NewBinding(
+			key.WithKeys(context.Config.Dashboard.Redo.Keys()...),
+			key.WithHelp(strings.Join(context.Config.Dashboard.Redo.Keys(), "/"), "redo"),
 		),
+		Trash: key.NewBinding(
+			key.WithKeys(context.Config.Dashboard.Trash.Keys()...),
+			key.WithHelp(strings.Join(context.Config.Dashboard.Trash.Keys(), "/"), "trash"),
+		),
+	}
+}
+
+// Initializes a new instance of dashboard screen
+func InitializeDashboardScreen(username string, vault *tlockvault.Vault, context *context.Context) modelmanager.Screen {
+	// Status bar
+	statusbar := components.NewStatusBar(username)
+
+	// Load keybindings for the user
+	userConfig, configErr := config.LoadUserConfig(username)
+	context.Config = userConfig
+	context.ConfigModTime = config.UserConfigModTime(username)
+
+	// Load the theme of the user
+	context.SetUser(username)
+
+	// Initialize dashboard keymap
+	dashboardKeys = buildDashboardKeys(context)
+
+	// Changes are recorded in the audit log as made by the user
+	vault.SetActor(username)
+
+	// Forget the items which have been in the trash for too long
+	vault.PurgeExpiredTrash(context.Config.TrashRetention())
+
+	// Use the clipboard backend from the config
+	clipboard.Configure(context.Config.ClipboardBackend, context.Config.ClipboardCommand)
+
+	// Show which values of the config could not be used
+	if configErr != nil {
+		statusbar.Message = configErrorMessage(configErr)
+		statusbar.ErrorMessage = true
 	}
 
 	return DashboardScreen{
 		vault:     vault,
 		context:   context,
-		statusbar: components.NewStatusBar(username),
+		statusbar: statusbar,
 		folders:   folders.InitializeFolders(vault, context),
 		tokens:    tokens.InitializeTokens(vault, context),
 	}
 }
 
+// Returns the config error as a single line for the status bar
+func configErrorMessage(err error) string {
+	lines := strings.Split(err.Error(), "\n")
+	message := lines[0]
+
+	// Errors like `yaml: unmarshal errors:` list the details on the next lines
+	if strings.HasSuffix(message, ":") && len(lines) > 1 {
+		message = fmt.Sprintf("%s %s", message, strings.TrimSpace(lines[1]))
+		lines = lines[1:]
+	}
+
+	// Point to the full list
+	if len(lines) > 1 {
+		message = fmt.Sprintf("%s (and %d more, run `tlock config check`)", message, len(lines)-1)
+	}
+
+	return message
+}
+
+// Reloads the config of the logged in user if the file has been changed
+// The invalid values fall back to the default ones, and are reported on the status bar
+// It is called by the root model, as the dashboard does not get the messages while another screen is on top of it
+func ReloadConfig(context *context.Context) tea.Cmd {
+	if context.CurrentUser == "" {
+		return nil
+	}
+
+	// Check if the file has been changed
+	modTime := config.UserConfigModTime(context.CurrentUser)
+
+	if modTime.Equal(context.ConfigModTime) {
+		return nil
+	}
+
+	context.ConfigModTime = modTime
+
+	// Load
+	userConfig, err := config.LoadUserConfig(context.CurrentUser)
+
+	// Apply
+	context.Config = userConfig
+	clipboard.Configure(userConfig.ClipboardBackend, userConfig.ClipboardCommand)
+
+	status := components.StatusBarMsg{Message: "Reloaded config"}
+
+	if err != nil {
+		status = components.StatusBarMsg{Message: configErrorMessage(err), ErrorMessage: true}
+	}
+
+	return tea.Batch(
+		func() tea.Msg { return tlockmessages.ConfigReloadedMsg{} },
+		func() tea.Msg { return status },
+	)
+}
+
 // Init
 func (screen DashboardScreen) Init() tea.Cmd {
 	var cmd tea.Cmd
 
 	if len(screen.vault.Folders) != 0 {
 		cmd = func() tea.Msg {
-			return tlockmessages.FolderChanged{
-				Folder: screen.vault.Folders[0],
-			}
+			return tlockmessages.RequestFolderChanged{}
 		}
 	}
 
-	return tea.Batch(cmd, tlockmessages.DispatchRefreshTokensValueMsg())
+	return tea.Batch(cmd, tlockstyles.ApplyTheme(screen.context.GetCurrentTheme()), tlockmessages.DispatchRefreshTokensValueMsg())
 }
 
 // Update
@@ -128,12 +230,49 @@ func (screen DashboardScreen) Update(msg tea.Msg, manager *modelmanager.ModelMan
 		// Themes screen
 		case key.Matches(msgType, dashboardKeys.ChangeTheme):
 			cmd = manager.PushScreen(InitializeThemesScreen(screen.context))
+
+		// Trash screen
+		case key.Matches(msgType, dashboardKeys.Trash):
+			cmd = manager.PushScreen(trash.InitializeTrashScreen(screen.vault, screen.context))
+
+		// Undo and redo
+		case key.Matches(msgType, dashboardKeys.Undo):
+			cmd = applyHistoryChange(screen.vault.Undo, "Undid")
+
+		case key.Matches(msgType, dashboardKeys.Redo):
+			cmd = applyHistoryChange(screen.vault.Redo, "Redid")
 		}
+
+	case tlockmessages.ConfigReloadedMsg:
+		// Use the new keys
+		dashboardKeys = buildDashboardKeys(screen.context)
 	}
 
 	return screen, tea.Batch(screen.folders.Update(msg, manager), screen.tokens.Update(msg, manager), cmd, screen.statusbar.Update(msg))
 }
 
+// Undoes or redoes a change with the given function, and reports what the change was
+func applyHistoryChange(change func() (string, error), verb string) tea.Cmd {
+	description, err := change()
+
+	if err != nil {
+		return func() tea.Msg {
+			return components.StatusBarMsg{Message: err.Error(), ErrorMessage: true}
+		}
+	}
+
+	// The focused folder may be gone, so it is asked for again after the refresh
+	return tea.Batch(
+		tea.Sequence(
+			func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+			func() tea.Msg { return tlockmessages.RequestFolderChanged{} },
+		),
+		func() tea.Msg {
+			return components.StatusBarMsg{Message: fmt.Sprintf("%s: %s", verb, description)}
+		},
+	)
+}
+
 // View
 func (screen DashboardScreen) View() string {
 	// Get the size of the terminal
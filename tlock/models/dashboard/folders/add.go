@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
@@ -39,16 +40,7 @@ func (k addFolderKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var addFolderKeys = addFolderKeyMap{
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "create folder"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var addFolderKeys addFolderKeyMap
 
 // Add folder screen
 type AddFolderScreen struct {
@@ -58,12 +50,21 @@ type AddFolderScreen struct {
 	// Error
 	errorMessage *error
 
+	// Folder to add the new folder inside of, empty for the top level
+	parent string
+
 	// Vault
 	vault *tlockvault.Vault
 }
 
 // Initialize add folder scree
-func InitializeAddFolderScreen(vault *tlockvault.Vault) AddFolderScreen {
+func InitializeAddFolderScreen(vault *tlockvault.Vault, context *context.Context) AddFolderScreen {
+	// Initialize keys
+	addFolderKeys = addFolderKeyMap{
+		Enter:  context.Config.Navigation.Confirm.WithHelp("create folder"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	// Initialize input box
 	name := components.InitializeInputBox("Your folder name goes here...")
 	name.Focus()
@@ -75,6 +76,14 @@ func InitializeAddFolderScreen(vault *tlockvault.Vault) AddFolderScreen {
 	}
 }
 
+// Initialize add folder screen for a folder inside of the parent
+func InitializeAddSubfolderScreen(parent tlockvault.Folder, vault *tlockvault.Vault, context *context.Context) AddFolderScreen {
+	screen := InitializeAddFolderScreen(vault, context)
+	screen.parent = parent.Name
+
+	return screen
+}
+
 // Init
 func (screen AddFolderScreen) Init() tea.Cmd {
 	return nil
@@ -94,7 +103,13 @@ func (screen AddFolderScreen) Update(msg tea.Msg, manager *modelmanager.ModelMan
 
 		case key.Matches(msgType, addFolderKeys.Enter):
 			// Add the folder
-			if err := screen.vault.AddFolder(screen.name.Value()); err != nil {
+			add := screen.vault.AddFolder
+
+			if screen.parent != "" {
+				add = func(name string) error { return screen.vault.AddSubfolder(screen.parent, name) }
+			}
+
+			if err := add(screen.name.Value()); err != nil {
 				screen.errorMessage = &err
 				break
 			}
@@ -119,10 +134,16 @@ func (screen AddFolderScreen) Update(msg tea.Msg, manager *modelmanager.ModelMan
 
 // View
 func (screen AddFolderScreen) View() string {
+	desc := "Add a new folder"
+
+	if screen.parent != "" {
+		desc = fmt.Sprintf("Add a new folder inside of %s", screen.vault.FolderPath(screen.parent))
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
 		tlockstyles.Title(addFolderAscii), "",
-		tlockstyles.Dimmed("Add a new folder"), "",
+		tlockstyles.Dimmed(desc), "",
 		components.InputGroup("Name", "Choose a name for your folder, like Socials!", screen.errorMessage, screen.name),
 		tlockstyles.HelpView(addFolderKeys),
 	)
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
@@ -37,16 +38,7 @@ func (k deleteFolderKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var deleteFolderKeys = deleteFolderKeyMap{
-	Delete: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "delete"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var deleteFolderKeys deleteFolderKeyMap
 
 // Delete folder screen
 type DeleteFolderScreen struct {
@@ -58,7 +50,13 @@ type DeleteFolderScreen struct {
 }
 
 // Initialize root model
-func InitializeDeleteFolderScreen(folder tlockvault.Folder, vault *tlockvault.Vault) DeleteFolderScreen {
+func InitializeDeleteFolderScreen(folder tlockvault.Folder, vault *tlockvault.Vault, context *context.Context) DeleteFolderScreen {
+	// Initialize keys
+	deleteFolderKeys = deleteFolderKeyMap{
+		Delete: context.Config.Navigation.Confirm.WithHelp("delete"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	return DeleteFolderScreen{
 		folder: folder,
 		vault:  vault,
@@ -80,14 +78,20 @@ func (screen DeleteFolderScreen) Update(msg tea.Msg, manager *modelmanager.Model
 		case key.Matches(msgType, deleteFolderKeys.GoBack):
 			manager.PopScreen()
 		case key.Matches(msgType, deleteFolderKeys.Delete):
-			// Delete the folder
-			screen.vault.DeleteFolder(screen.folder.Name)
+			// Delete the folder, the tokens of a smart folder are kept
+			if screen.folder.IsSmart() {
+				screen.vault.DeleteSmartFolder(screen.folder.Name)
+			} else {
+				screen.vault.DeleteFolder(screen.folder.Name)
+			}
 
 			// Request folders refresh
 			cmds = append(
 				cmds,
-				func() tea.Msg { return tlockmessages.RequestFolderChanged{} },
-				func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+				tea.Sequence(
+					func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+					func() tea.Msg { return tlockmessages.RequestFolderChanged{} },
+				),
 				func() tea.Msg {
 					return components.StatusBarMsg{Message: fmt.Sprintf("Successfully deleted %s folder", screen.folder.Name)}
 				},
@@ -103,10 +107,18 @@ func (screen DeleteFolderScreen) Update(msg tea.Msg, manager *modelmanager.Model
 
 // View
 func (screen DeleteFolderScreen) View() string {
+	desc := "Move the folder and its tokens to the trash"
+
+	if screen.folder.IsSmart() {
+		desc = "Delete the smart folder, its tokens are kept in their folders"
+	} else if screen.vault.HasSubfolders(screen.folder.Name) {
+		desc = "Move the folder and its tokens to the trash, its subfolders are moved to its parent"
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
 		tlockstyles.Title(deleteFolderAsciiArt), "",
-		tlockstyles.Dimmed("Permanently delete tokens folder"), "",
+		tlockstyles.Dimmed(desc), "",
 		lipgloss.JoinHorizontal(
 			lipgloss.Center,
 			tlockstyles.Dimmed("Are you sure you want to "),
@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
@@ -39,16 +40,7 @@ func (k editFolderKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var editFolderKeys = editFolderKeyMap{
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "edit folder"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var editFolderKeys editFolderKeyMap
 
 // Edit folder screen
 type EditFolderScreen struct {
@@ -66,7 +58,13 @@ type EditFolderScreen struct {
 }
 
 // Initialize edit folder screen
-func InitializeEditFolderScreen(folder tlockvault.Folder, vault *tlockvault.Vault) EditFolderScreen {
+func InitializeEditFolderScreen(folder tlockvault.Folder, vault *tlockvault.Vault, context *context.Context) EditFolderScreen {
+	// Initialize keys
+	editFolderKeys = editFolderKeyMap{
+		Enter:  context.Config.Navigation.Confirm.WithHelp("edit folder"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	// Initialize input box
 	name := components.InitializeInputBox("Your folder name goes here...")
 	name.SetValue(folder.Name)
@@ -105,8 +103,11 @@ func (screen EditFolderScreen) Update(msg tea.Msg, manager *modelmanager.ModelMa
 				break
 			}
 
-			// Request folders refresh
-			cmds = append(cmds, func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} })
+			// Request folders refresh, and refocus the folder so the tokens use its new name
+			cmds = append(cmds, tea.Sequence(
+				func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+				func() tea.Msg { return tlockmessages.RequestFolderChanged{} },
+			))
 			cmds = append(cmds, func() tea.Msg {
 				return components.StatusBarMsg{Message: fmt.Sprintf("Successfully renamed %s to %s!", screen.folder.Name, screen.name.Value())}
 			})
@@ -25,7 +25,22 @@ func foldersWidth(width int) int {
 	return int(math.Floor((1.0 / 5.0) * float64(width)))
 }
 
-type folderListItem tlockvault.Folder
+// Folder list item
+type folderListItem struct {
+	tlockvault.Folder
+
+	// Number of tokens, including the ones in the subfolders
+	count int
+
+	// Number of parents above the folder
+	depth int
+
+	// Whether the folder has subfolders
+	hasSubfolders bool
+
+	// Whether the subfolders are hidden
+	collapsed bool
+}
 
 func (item folderListItem) FilterValue() string {
 	return item.Name
@@ -53,6 +68,18 @@ func (d folderListDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
 func (d folderListDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	item := listItem.(folderListItem)
 
+	// Smart folders show their query
+	if item.IsSmart() {
+		render_fn := components.InactiveSmartFolderListItem
+
+		if index == m.Index() {
+			render_fn = components.ActiveSmartFolderListItem
+		}
+
+		fmt.Fprint(w, render_fn(m.Width(), item.Name, item.Query, len(item.Tokens)))
+		return
+	}
+
 	// Decide renderer function
 	render_fn := components.InactiveFolderListItem
 
@@ -60,8 +87,17 @@ func (d folderListDelegate) Render(w io.Writer, m list.Model, index int, listIte
 		render_fn = components.ActiveFolderListItem
 	}
 
+	// Show if the subfolders are hidden
+	name := item.Name
+
+	if item.hasSubfolders && item.collapsed {
+		name = "▸ " + name
+	} else if item.hasSubfolders {
+		name = "▾ " + name
+	}
+
 	// Print
-	fmt.Fprint(w, render_fn(m.Width(), item.Name, len(item.Tokens)))
+	fmt.Fprint(w, render_fn(m.Width(), name, item.count, item.depth))
 }
 
 // Folders
@@ -78,32 +114,56 @@ type Folders struct {
 
 	// Context
 	context *context.Context
+
+	// Folders whose subfolders are hidden
+	collapsed map[string]bool
 }
 
 // Returns the folders in the form of list item
-func buildFolderListItems(vault *tlockvault.Vault) []list.Item {
-	// Mapper function
-	mapper := func(folder tlockvault.Folder) list.Item {
-		return folderListItem(folder)
+func buildFolderListItems(vault *tlockvault.Vault, collapsed map[string]bool) []list.Item {
+	// Mapper functions
+	virtualMapper := func(folder tlockvault.Folder) list.Item {
+		return folderListItem{Folder: folder, count: len(folder.Tokens)}
+	}
+
+	mapper := func(node tlockvault.FolderNode) list.Item {
+		return folderListItem{
+			Folder:        node.Folder,
+			count:         vault.TokenCount(node.Folder.Name),
+			depth:         node.Depth,
+			hasSubfolders: node.HasSubfolders,
+			collapsed:     collapsed[node.Folder.Name],
+		}
 	}
 
-	// Map folders
-	return utils.Map(vault.Folders, mapper)
+	// Map folders, with the favorites and the smart folders pinned at the top
+	virtual := append([]tlockvault.Folder{vault.Favorites()}, vault.SmartFolderViews()...)
+
+	return append(utils.Map(virtual, virtualMapper), utils.Map(vault.FolderTree(collapsed), mapper)...)
 }
 
 // Builds the listview for the given list of folders
-func buildListViewForFolders(vault *tlockvault.Vault, context *context.Context) list.Model {
+func buildListViewForFolders(vault *tlockvault.Vault, context *context.Context, collapsed map[string]bool) list.Model {
 	// Get size
 	width, _, _ := term.GetSize(int(os.Stdout.Fd()))
 
 	// Build listview
 	// Height will be auto handled by the view function
-	listview := components.ListViewSimple(buildFolderListItems(vault), folderListDelegate{}, foldersWidth(width), 0) // -4 is for the title
+	listview := components.ListViewSimple(buildFolderListItems(vault, collapsed), folderListDelegate{}, foldersWidth(width), 0) // -4 is for the title
 
 	// Use custom keys
 	listview.KeyMap.CursorUp = context.Config.Folder.Previous.Binding
 	listview.KeyMap.CursorDown = context.Config.Folder.Next.Binding
 
+	// Unbind the paging keys (like f and d), they are used by the dashboard
+	listview.KeyMap.NextPage = key.NewBinding()
+	listview.KeyMap.PrevPage = key.NewBinding()
+
+	// Start at the first folder unless there are favorites
+	if len(vault.Folders) != 0 && len(vault.Favorites().Tokens) == 0 {
+		listview.Select(firstFolderIndex(vault))
+	}
+
 	// Return listview
 	return listview
 }
@@ -116,11 +176,14 @@ func InitializeFolders(vault *tlockvault.Vault, context *context.Context) Folder
 		lastFocused = 0
 	}
 
+	collapsed := make(map[string]bool)
+
 	return Folders{
 		vault:       vault,
 		context:     context,
-		listview:    buildListViewForFolders(vault, context),
+		listview:    buildListViewForFolders(vault, context, collapsed),
 		lastFocused: lastFocused,
+		collapsed:   collapsed,
 	}
 }
 
@@ -132,7 +195,7 @@ func (folders Folders) Focused() *tlockvault.Folder {
 	}
 
 	// Get the focused item
-	focusedItem := tlockvault.Folder(folders.listview.Items()[folders.listview.Index()].(folderListItem))
+	focusedItem := folders.listview.Items()[folders.listview.Index()].(folderListItem).Folder
 
 	// Return
 	return &focusedItem
@@ -149,18 +212,69 @@ func (folders *Folders) Update(msg tea.Msg, manager *modelmanager.ModelManager)
 		switch {
 		// Add new folder
 		case key.Matches(msgType, folders.context.Config.Folder.Add.Binding):
-			cmds = append(cmds, manager.PushScreen(InitializeAddFolderScreen(folders.vault)))
+			cmds = append(cmds, manager.PushScreen(InitializeAddFolderScreen(folders.vault, folders.context)))
+
+		// Add new folder inside of the focused one
+		case key.Matches(msgType, folders.context.Config.Folder.AddSubfolder.Binding):
+			if focused := folders.Focused(); focused != nil && focused.Virtual {
+				cmds = append(cmds, virtualFolderMsg(*focused, "nested"))
+			} else if focused != nil {
+				// Show the new subfolder
+				delete(folders.collapsed, focused.Name)
+
+				cmds = append(cmds, manager.PushScreen(InitializeAddSubfolderScreen(*focused, folders.vault, folders.context)))
+			}
+
+		// Move the focused folder inside of another one
+		case key.Matches(msgType, folders.context.Config.Folder.MoveInto.Binding):
+			if focused := folders.Focused(); focused != nil && focused.Virtual {
+				cmds = append(cmds, virtualFolderMsg(*focused, "nested"))
+			} else if focused != nil {
+				cmds = append(cmds, manager.PushScreen(InitializeMoveFolderScreen(*focused, folders.vault, folders.context)))
+			}
+
+		// Show the subfolders
+		case key.Matches(msgType, folders.context.Config.Folder.Expand.Binding):
+			if focused := folders.Focused(); focused != nil && folders.collapsed[focused.Name] {
+				delete(folders.collapsed, focused.Name)
+				cmds = append(cmds, folders.refresh())
+			}
+
+		// Hide the subfolders, or focus the parent folder
+		case key.Matches(msgType, folders.context.Config.Folder.Collapse.Binding):
+			focused := folders.Focused()
+
+			if focused == nil || focused.Virtual {
+				break
+			}
+
+			if folders.listview.Items()[folders.listview.Index()].(folderListItem).hasSubfolders && !folders.collapsed[focused.Name] {
+				folders.collapsed[focused.Name] = true
+				cmds = append(cmds, folders.refresh())
+			} else if focused.Parent != "" && folders.focusFolder(focused.Parent) {
+				cmds = append(cmds, func() tea.Msg { return tlockmessages.RequestFolderChanged{} })
+			}
+
+		// Add new smart folder
+		case key.Matches(msgType, folders.context.Config.Folder.AddSmart.Binding):
+			cmds = append(cmds, manager.PushScreen(InitializeAddSmartFolderScreen(folders.vault, folders.context)))
 
 		// Edit focused token
 		case key.Matches(msgType, folders.context.Config.Folder.Edit.Binding):
-			if focused := folders.Focused(); focused != nil {
-				cmds = append(cmds, manager.PushScreen(InitializeEditFolderScreen(*focused, folders.vault)))
+			if focused := folders.Focused(); focused != nil && focused.IsSmart() {
+				cmds = append(cmds, manager.PushScreen(InitializeEditSmartFolderScreen(*focused, folders.vault, folders.context)))
+			} else if focused != nil && focused.Virtual {
+				cmds = append(cmds, virtualFolderMsg(*focused, "edited"))
+			} else if focused != nil {
+				cmds = append(cmds, manager.PushScreen(InitializeEditFolderScreen(*focused, folders.vault, folders.context)))
 			}
 
 		// Delete focused token
 		case key.Matches(msgType, folders.context.Config.Folder.Delete.Binding):
-			if focused := folders.Focused(); focused != nil {
-				cmds = append(cmds, manager.PushScreen(InitializeDeleteFolderScreen(*focused, folders.vault)))
+			if focused := folders.Focused(); focused != nil && focused.Virtual && !focused.IsSmart() {
+				cmds = append(cmds, virtualFolderMsg(*focused, "deleted"))
+			} else if focused != nil {
+				cmds = append(cmds, manager.PushScreen(InitializeDeleteFolderScreen(*focused, folders.vault, folders.context)))
 			}
 
 		case key.Matches(msgType, folders.context.Config.Folder.Next.Binding):
@@ -171,15 +285,14 @@ func (folders *Folders) Update(msg tea.Msg, manager *modelmanager.ModelManager)
 
 		// Move folder down
 		case key.Matches(msgType, folders.context.Config.Folder.MoveUp.Binding):
-			if focused := folders.Focused(); focused != nil {
+			if focused := folders.Focused(); focused != nil && focused.Virtual {
+				cmds = append(cmds, virtualFolderMsg(*focused, "moved"))
+			} else if focused != nil {
 				if folders.vault.MoveFolderUp(focused.Name) {
-					// Move cursor down
-					folders.listview.CursorUp()
-
-					// Refresh
+					// Refresh, the focus moves along with the folder
 					cmds = append(
 						cmds,
-						func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+						folders.refresh(),
 						func() tea.Msg {
 							return components.StatusBarMsg{Message: fmt.Sprintf("Successfully moved %s folder up", focused.Name)}
 						},
@@ -189,15 +302,14 @@ func (folders *Folders) Update(msg tea.Msg, manager *modelmanager.ModelManager)
 
 		// Move folder down
 		case key.Matches(msgType, folders.context.Config.Folder.MoveDown.Binding):
-			if focused := folders.Focused(); focused != nil {
+			if focused := folders.Focused(); focused != nil && focused.Virtual {
+				cmds = append(cmds, virtualFolderMsg(*focused, "moved"))
+			} else if focused != nil {
 				if folders.vault.MoveFolderDown(focused.Name) {
-					// Move cursor down
-					folders.listview.CursorDown()
-
-					// Refresh
+					// Refresh, the focus moves along with the folder
 					cmds = append(
 						cmds,
-						func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+						folders.refresh(),
 						func() tea.Msg {
 							return components.StatusBarMsg{Message: fmt.Sprintf("Successfully moved %s folder down", focused.Name)}
 						},
@@ -206,13 +318,22 @@ func (folders *Folders) Update(msg tea.Msg, manager *modelmanager.ModelManager)
 			}
 		}
 
+	// Use the new keys on config reloaded message
+	case tlockmessages.ConfigReloadedMsg:
+		folders.listview.KeyMap.CursorUp = folders.context.Config.Folder.Previous.Binding
+		folders.listview.KeyMap.CursorDown = folders.context.Config.Folder.Next.Binding
+
 	// Update items on refresh folders message
 	case tlockmessages.RefreshFoldersMsg:
 		// Add
-		cmds = append(cmds, folders.listview.SetItems(buildFolderListItems(folders.vault)))
+		cmds = append(cmds, folders.refresh())
+
+		// If this is the first folder, might as well focus it and post request for folder changed
+		if len(folders.vault.Folders) == 1 {
+			if folders.listview.Index() < firstFolderIndex(folders.vault) && len(folders.vault.Favorites().Tokens) == 0 {
+				folders.listview.Select(firstFolderIndex(folders.vault))
+			}
 
-		// If this is the first element, might as well post request for folder changed
-		if len(folders.listview.Items()) == 1 {
 			cmds = append(cmds, func() tea.Msg { return tlockmessages.RequestFolderChanged{} })
 		}
 
@@ -240,6 +361,49 @@ func (folders *Folders) Update(msg tea.Msg, manager *modelmanager.ModelManager)
 	return tea.Batch(cmds...)
 }
 
+// Rebuilds the list items, keeping the focus on the same folder if it still exists
+func (folders *Folders) refresh() tea.Cmd {
+	focused := folders.Focused()
+
+	cmd := folders.listview.SetItems(buildFolderListItems(folders.vault, folders.collapsed))
+
+	if focused != nil && !focused.Virtual {
+		folders.focusFolder(focused.Name)
+	}
+
+	return cmd
+}
+
+// Moves the focus to the folder with the given name, returns false if it is not shown
+func (folders *Folders) focusFolder(name string) bool {
+	for index, item := range folders.listview.Items() {
+		if item := item.(folderListItem); !item.Virtual && item.Name == name {
+			folders.listview.Select(index)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the index of the first folder in the list, which is after the virtual folders
+func firstFolderIndex(vault *tlockvault.Vault) int {
+	return 1 + len(vault.SmartFolders)
+}
+
+// Status bar message for when the focused folder is virtual
+func virtualFolderMsg(folder tlockvault.Folder, action string) tea.Cmd {
+	message := fmt.Sprintf("The favorites cannot be %s", action)
+
+	if folder.IsSmart() {
+		message = fmt.Sprintf("Smart folders cannot be %s", action)
+	}
+
+	return func() tea.Msg {
+		return components.StatusBarMsg{Message: message, ErrorMessage: true}
+	}
+}
+
 // View
 func (folders Folders) View() string {
 	_, height, _ := term.GetSize(int(os.Stdout.Fd()))
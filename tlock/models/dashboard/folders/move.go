@@ -0,0 +1,210 @@
+package folders
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+)
+
+// Parent folder list item
+type moveFolderListItem struct {
+	// Name of the parent, empty for the top level
+	name string
+
+	// Number of parents above the folder
+	depth int
+}
+
+func (item moveFolderListItem) FilterValue() string {
+	return item.name
+}
+
+// Parent folder list view delegate
+type moveFolderDelegate struct{}
+
+// Height
+func (delegate moveFolderDelegate) Height() int {
+	return 3
+}
+
+// Spacing
+func (delegate moveFolderDelegate) Spacing() int {
+	return 0
+}
+
+// Update
+func (d moveFolderDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
+	return nil
+}
+
+// Render
+func (d moveFolderDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(moveFolderListItem)
+
+	if !ok {
+		return
+	}
+
+	// Decide the renderer based on focused index
+	renderer := components.ListItemInactive
+
+	if index == m.Index() {
+		renderer = components.ListItemActive
+	}
+
+	name := item.name
+
+	if name == "" {
+		name = "Top level"
+	}
+
+	// Render
+	fmt.Fprint(w, renderer(65, strings.Repeat("  ", item.depth)+name, "›"))
+}
+
+var moveFolderAscii = `
+█▀▄▀█ █▀█ █ █ █▀▀
+█ ▀ █ █▄█ ▀▄▀ ██▄`
+
+// Move folder key map
+type moveFolderKeyMap struct {
+	GoBack key.Binding
+	Move   key.Binding
+}
+
+// ShortHelp()
+func (k moveFolderKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.GoBack, k.Move}
+}
+
+// FullHelp()
+func (k moveFolderKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.GoBack},
+		{k.Move},
+	}
+}
+
+// Keys
+var moveFolderKeys moveFolderKeyMap
+
+// Move folder screen
+type MoveFolderScreen struct {
+	// Vault
+	vault *tlockvault.Vault
+
+	// Folder to move
+	folder tlockvault.Folder
+
+	// Listview
+	listview list.Model
+}
+
+// Initialize move folder screen
+func InitializeMoveFolderScreen(folder tlockvault.Folder, vault *tlockvault.Vault, context *context.Context) MoveFolderScreen {
+	// Initialize keys
+	moveFolderKeys = moveFolderKeyMap{
+		Move:   context.Config.Navigation.Confirm.WithHelp("move"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
+	// The folder cannot be moved inside of itself, so its subfolders are skipped
+	items := []list.Item{moveFolderListItem{}}
+	skipBelow := -1
+
+	for _, node := range vault.FolderTree(nil) {
+		if skipBelow != -1 && node.Depth > skipBelow {
+			continue
+		}
+
+		skipBelow = -1
+
+		if node.Folder.Name == folder.Name {
+			skipBelow = node.Depth
+			continue
+		}
+
+		items = append(items, moveFolderListItem{name: node.Folder.Name, depth: node.Depth + 1})
+	}
+
+	// Initialize listview
+	listview := components.ListViewSimple(items, moveFolderDelegate{}, 65, min(15, len(items)*3))
+	listview.KeyMap.CursorUp = context.Config.Navigation.Up.Binding
+	listview.KeyMap.CursorDown = context.Config.Navigation.Down.Binding
+
+	return MoveFolderScreen{
+		vault:    vault,
+		folder:   folder,
+		listview: listview,
+	}
+}
+
+// Init
+func (screen MoveFolderScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update
+func (screen MoveFolderScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msgType, moveFolderKeys.GoBack):
+			manager.PopScreen()
+
+		case key.Matches(msgType, moveFolderKeys.Move):
+			parent := screen.listview.Items()[screen.listview.Index()].(moveFolderListItem)
+
+			// Move folder
+			if err := screen.vault.SetParent(screen.folder.Name, parent.name); err != nil {
+				cmds = append(cmds, func() tea.Msg {
+					return components.StatusBarMsg{Message: err.Error(), ErrorMessage: true}
+				})
+			} else {
+				message := fmt.Sprintf("Successfully moved %s folder inside of %s", screen.folder.Name, parent.name)
+
+				if parent.name == "" {
+					message = fmt.Sprintf("Successfully moved %s folder to the top level", screen.folder.Name)
+				}
+
+				// Require refresh of folders list
+				cmds = append(
+					cmds,
+					func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+					func() tea.Msg { return components.StatusBarMsg{Message: message} },
+				)
+			}
+
+			// Pop
+			manager.PopScreen()
+		}
+	}
+
+	screen.listview, _ = screen.listview.Update(msg)
+
+	return screen, tea.Batch(cmds...)
+}
+
+// View
+func (screen MoveFolderScreen) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		tlockstyles.Title(moveFolderAscii), "",
+		tlockstyles.Dimmed(fmt.Sprintf("Select the folder to move %s inside of", screen.folder.Name)), "",
+		screen.listview.View(), "",
+		tlockstyles.HelpView(moveFolderKeys),
+	)
+}
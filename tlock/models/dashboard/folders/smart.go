@@ -0,0 +1,191 @@
+package folders
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/config"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	"github.com/eklairs/tlock/tlock-internal/form"
+	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+)
+
+// Smart folder key map
+type smartFolderKeyMap struct {
+	GoBack key.Binding
+	Enter  key.Binding
+	Tab    key.Binding
+}
+
+// ShortHelp()
+func (k smartFolderKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Tab, k.Enter, k.GoBack}
+}
+
+// FullHelp()
+func (k smartFolderKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Tab},
+		{k.Enter},
+		{k.GoBack},
+	}
+}
+
+// Keys
+var smartFolderKeys smartFolderKeyMap
+
+// Query validator
+func queryValidator(_ *tlockvault.Vault, query string) error {
+	_, err := tlockvault.ParseQuery(query)
+
+	return err
+}
+
+// Add or edit smart folder screen
+type SmartFolderScreen struct {
+	// Form with the name and the query
+	form form.Form
+
+	// Smart folder being edited, nil if a new one is being added
+	folder *tlockvault.Folder
+
+	// Vault
+	vault *tlockvault.Vault
+}
+
+// Initialize add smart folder screen
+func InitializeAddSmartFolderScreen(vault *tlockvault.Vault, context *context.Context) SmartFolderScreen {
+	return initializeSmartFolderScreen(nil, vault, context, "create smart folder")
+}
+
+// Initialize edit smart folder screen
+func InitializeEditSmartFolderScreen(folder tlockvault.Folder, vault *tlockvault.Vault, context *context.Context) SmartFolderScreen {
+	return initializeSmartFolderScreen(&folder, vault, context, "edit smart folder")
+}
+
+// Initializes the smart folder screen, with the values of the folder if it is being edited
+func initializeSmartFolderScreen(folder *tlockvault.Folder, vault *tlockvault.Vault, context *context.Context, action string) SmartFolderScreen {
+	// Initialize keys
+	smartFolderKeys = smartFolderKeyMap{
+		Enter:  context.Config.Navigation.Confirm.WithHelp(action),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+		Tab:    config.Combine("switch input", context.Config.Navigation.NextInput, context.Config.Navigation.PreviousInput),
+	}
+
+	// Inputs
+	name := components.InitializeInputBox("Your folder name goes here...")
+	query := components.InitializeInputBox("Like issuer:aws tag:prod type:totp")
+
+	if folder != nil {
+		name.SetValue(folder.Name)
+		query.SetValue(folder.Query)
+	}
+
+	// Initialize form
+	smartForm := form.New(form.KeyMap{
+		Next:     context.Config.Navigation.NextInput.Binding,
+		Previous: context.Config.Navigation.PreviousInput.Binding,
+		Submit:   context.Config.Navigation.Confirm.Binding,
+		Left:     context.Config.Navigation.OptionLeft.Binding,
+		Right:    context.Config.Navigation.OptionRight.Binding,
+	})
+
+	smartForm.AddInput("name", "Name", "Choose a name for your smart folder", name, []form.Validator{})
+	smartForm.AddInput("query", "Query", "Tokens of every folder that match it are shown", query, []form.Validator{queryValidator})
+	smartForm.PostInit()
+
+	return SmartFolderScreen{
+		form:   smartForm,
+		folder: folder,
+		vault:  vault,
+	}
+}
+
+// Init
+func (screen SmartFolderScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update
+func (screen SmartFolderScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msgType, smartFolderKeys.GoBack):
+			manager.PopScreen()
+		}
+
+	case form.FormSubmittedMsg:
+		name, query := msgType.Data["name"], msgType.Data["query"]
+
+		// Add or update the folder
+		var err error
+		message := fmt.Sprintf("Successfully created %s smart folder", name)
+
+		if screen.folder == nil {
+			err = screen.vault.AddSmartFolder(name, query)
+		} else {
+			err = screen.vault.EditSmartFolder(screen.folder.Name, name, query)
+			message = fmt.Sprintf("Successfully edited %s smart folder", name)
+		}
+
+		// The query is already validated, so it is about the name
+		if err != nil {
+			screen.form.Items[0].FormItem.SetError(&err)
+			break
+		}
+
+		// Request folders refresh, and then show the tokens of the focused folder
+		cmds = append(
+			cmds,
+			tea.Sequence(
+				func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+				func() tea.Msg { return tlockmessages.RequestFolderChanged{} },
+			),
+			func() tea.Msg { return components.StatusBarMsg{Message: message} },
+		)
+
+		// Pop
+		manager.PopScreen()
+	}
+
+	// Let the form handle its update
+	cmds = append(cmds, screen.form.Update(msg, screen.vault))
+
+	// Return
+	return screen, tea.Batch(cmds...)
+}
+
+// View
+func (screen SmartFolderScreen) View() string {
+	ascii, desc := addFolderAscii, "Show the tokens of every folder that match a query"
+
+	if screen.folder != nil {
+		ascii, desc = editFolderAscii, "Change the name or the query of the smart folder"
+	}
+
+	// Number of tokens that match the query as it is typed
+	matches := ""
+
+	if _, err := tlockvault.ParseQuery(screen.form.Items[1].FormItem.Value()); err == nil {
+		matches = fmt.Sprintf("%d tokens match the query", len(screen.vault.QueryTokens(screen.form.Items[1].FormItem.Value())))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		tlockstyles.Styles.Title.Render(ascii), "",
+		tlockstyles.Styles.SubText.Render(desc), "",
+		screen.form.Items[0].FormItem.View(),
+		screen.form.Items[1].FormItem.View(),
+		tlockstyles.Dimmed(matches), "",
+		tlockstyles.Help.View(smartFolderKeys),
+	)
+}
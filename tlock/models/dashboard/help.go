@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -70,6 +71,26 @@ func BuildHelpMenu(context *context.Context) string {
 				Key:  m(context.Config.Folder.Add.Keys()),
 				Desc: "Add a new folder",
 			},
+			{
+				Key:  m(context.Config.Folder.AddSmart.Keys()),
+				Desc: "Add a new smart folder from a query",
+			},
+			{
+				Key:  m(context.Config.Folder.AddSubfolder.Keys()),
+				Desc: "Add a new folder inside of the focused folder",
+			},
+			{
+				Key:  m(context.Config.Folder.MoveInto.Keys()),
+				Desc: "Move the focused folder inside of another folder",
+			},
+			{
+				Key:  m(context.Config.Folder.Expand.Keys()),
+				Desc: "Show the subfolders of the focused folder",
+			},
+			{
+				Key:  m(context.Config.Folder.Collapse.Keys()),
+				Desc: "Hide the subfolders, or focus the parent folder",
+			},
 			{
 				Key:  m(context.Config.Folder.Edit.Keys()),
 				Desc: "Edit the current focused folder",
@@ -140,18 +161,74 @@ func BuildHelpMenu(context *context.Context) string {
 				Key:  m(context.Config.Tokens.Delete.Keys()),
 				Desc: "Delete the current focused token",
 			},
+			{
+				Key:  m(context.Config.Tokens.Reveal.Keys()),
+				Desc: "Reveal the code of the focused token [only in hidden mode]",
+			},
+			{
+				Key:  m(context.Config.Tokens.ShowAll.Keys()),
+				Desc: "Show or hide the codes of all the tokens",
+			},
+			{
+				Key:  m(context.Config.Tokens.SwitchLayout.Keys()),
+				Desc: "Switch between the cards, compact and grid layouts",
+			},
+			{
+				Key:  m(context.Config.Tokens.Sort.Keys()),
+				Desc: "Change the order of the tokens in the folder",
+			},
+			{
+				Key:  m(context.Config.Tokens.Favorite.Keys()),
+				Desc: "Add or remove the token from the favorites",
+			},
+			{
+				Key:  context.Config.Tokens.Select.HelpKeys(),
+				Desc: "Select the focused token for a bulk move, delete, tag or export",
+			},
+			{
+				Key:  m(context.Config.Tokens.SelectAll.Keys()),
+				Desc: "Select or unselect all the tokens in the folder",
+			},
+			{
+				Key:  m(context.Config.Tokens.Tag.Keys()),
+				Desc: "Add tags to the selected tokens",
+			},
+			{
+				Key:  m(context.Config.Tokens.Export.Keys()),
+				Desc: "Export the selected tokens as otpauth URIs",
+			},
+			{
+				Key:  m(context.Config.Tokens.Details.Keys()),
+				Desc: "Show the details of the focused token",
+			},
 		},
 		Others: []HelpKeyBindingSpec{
 			{
-				Key:  "?",
+				Key:  m(context.Config.Dashboard.Help.Keys()),
 				Desc: "Show this help window",
 			},
 			{
-				Key:  "ctrl+t",
+				Key:  m(context.Config.Dashboard.ChangeTheme.Keys()),
 				Desc: "Change theme",
 			},
 			{
-				Key:  "ctrl+c / ctrl+q",
+				Key:  m(context.Config.Dashboard.Trash.Keys()),
+				Desc: "Open the trash, to restore the deleted tokens and folders",
+			},
+			{
+				Key:  m(context.Config.Dashboard.Undo.Keys()),
+				Desc: "Undo the last change to the folders and tokens",
+			},
+			{
+				Key:  m(context.Config.Dashboard.Redo.Keys()),
+				Desc: "Redo the last undone change",
+			},
+			{
+				Key:  m(context.Config.Navigation.GoBack.Keys()),
+				Desc: "Go back to the previous screen",
+			},
+			{
+				Key:  m(context.Config.Global.Quit.Keys()),
 				Desc: "Exit the application",
 			},
 		},
@@ -170,6 +247,9 @@ func BuildHelpMenu(context *context.Context) string {
 // Help screen
 type HelpScreen struct {
 	viewport viewport.Model
+
+	// Context
+	context *context.Context
 }
 
 // Initializes a new instance of the help screen
@@ -181,6 +261,7 @@ func InitializeHelpScreen(context *context.Context) HelpScreen {
 
 	return HelpScreen{
 		viewport: viewport,
+		context:  context,
 	}
 }
 
@@ -193,8 +274,8 @@ func (screen HelpScreen) Init() tea.Cmd {
 func (screen HelpScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
 	switch msgType := msg.(type) {
 	case tea.KeyMsg:
-		switch msgType.String() {
-		case "esc":
+		switch {
+		case key.Matches(msgType, screen.context.Config.Navigation.GoBack.Binding):
 			manager.PopScreen()
 		}
 	case tea.WindowSizeMsg:
@@ -14,7 +14,6 @@ import (
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
 	"github.com/eklairs/tlock/tlock-internal/utils"
 	tlockstyles "github.com/eklairs/tlock/tlock/styles"
-	"github.com/muesli/termenv"
 )
 
 // Themes key map
@@ -36,24 +35,7 @@ func (k themesKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var themesKeys = themesKeyMap{
-	Esc: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-	Save: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "save"),
-	),
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "move up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "move down"),
-	),
-}
+var themesKeys themesKeyMap
 
 // Theme item
 type themeItem tlockcontext.Theme
@@ -92,7 +74,14 @@ func (d themeListDelegate) Render(w io.Writer, m list.Model, index int, listItem
 		render_fn = components.ListItemActive
 	}
 
-	fmt.Fprint(w, render_fn(m.Width()-6, item.Name, ""))
+	// Mark the themes loaded from the themes directory
+	suffix := ""
+
+	if item.Custom {
+		suffix = "custom"
+	}
+
+	fmt.Fprint(w, render_fn(m.Width()-6, item.Name, suffix))
 }
 
 var themesAsciiArt = `
@@ -110,14 +99,24 @@ type ThemesScreen struct {
 
 // Initializes a new instance of the themes screen
 func InitializeThemesScreen(context *tlockcontext.Context) ThemesScreen {
+	// Initialize keys
+	themesKeys = themesKeyMap{
+		Esc:  context.Config.Navigation.GoBack.WithHelp("go back"),
+		Save: context.Config.Navigation.Confirm.WithHelp("save"),
+		Up:   context.Config.Navigation.Up.WithHelp("move up"),
+		Down: context.Config.Navigation.Down.WithHelp("move down"),
+	}
+
 	// Theme items
 	themeItems := utils.Map(context.Themes, func(theme tlockcontext.Theme) list.Item { return themeItem(theme) })
 
 	// Initialize theme list
 	listview := components.ListViewSimple(themeItems, themeListDelegate{}, 65, min(18, len(context.Themes)*3))
+	listview.KeyMap.CursorUp = themesKeys.Up
+	listview.KeyMap.CursorDown = themesKeys.Down
 
 	// Set the focus to the currently applied theme
-	for i := 0; i < slices.IndexFunc(context.Themes, func(t tlockcontext.Theme) bool { return t.Name == context.TLockConfig.CurrentTheme }); i++ {
+	for i := 0; i < slices.IndexFunc(context.Themes, func(t tlockcontext.Theme) bool { return t.Name == context.GetCurrentTheme().Name }); i++ {
 		listview.CursorDown()
 	}
 
@@ -182,23 +181,34 @@ func (screen ThemesScreen) Update(msg tea.Msg, manager *modelmanager.ModelManage
 
 // View
 func (screen ThemesScreen) View() string {
-	return lipgloss.JoinVertical(
-		lipgloss.Center,
+	// List of items to render
+	items := []string{
 		tlockstyles.Title(themesAsciiArt), "",
 		tlockstyles.Dimmed("Choose a theme for tlock"), "",
+	}
+
+	// Show why the custom themes failed to load
+	if len(screen.context.ThemeErrors) != 0 {
+		for _, err := range screen.context.ThemeErrors {
+			items = append(items, tlockstyles.Styles.Error.Render(err.Error()))
+		}
+
+		items = append(items, "")
+	}
+
+	items = append(items,
 		screen.listview.View(), "",
 		components.Paginator(screen.listview), "",
 		tlockstyles.HelpView(themesKeys),
 	)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		items...,
+	)
 }
 
 // Sets the theme
 func (screen ThemesScreen) SetTheme(theme tlockcontext.Theme) tea.Cmd {
-	// Reinitialize styles
-	tlockstyles.InitializeStyles(theme)
-
-	// Change background color
-	return func() tea.Msg {
-		return tea.SetBackgroundColor(termenv.RGBColor(theme.Background))
-	}
+	return tlockstyles.ApplyTheme(theme)
 }
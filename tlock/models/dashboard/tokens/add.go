@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/config"
+	"github.com/eklairs/tlock/tlock-internal/context"
 	"github.com/eklairs/tlock/tlock-internal/form"
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
@@ -19,11 +21,12 @@ type addTokenKeyMap struct {
 	Enter  key.Binding
 	Tab    key.Binding
 	Arrow  key.Binding
+	Icons  key.Binding
 }
 
 // ShortHelp()
 func (k addTokenKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Tab, k.Arrow, k.Enter, k.GoBack}
+	return []key.Binding{k.Tab, k.Arrow, k.Icons, k.Enter, k.GoBack}
 }
 
 // FullHelp()
@@ -32,24 +35,7 @@ func (k addTokenKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var addTokenKeys = addTokenKeyMap{
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "create token"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-	Tab: key.NewBinding(
-		key.WithKeys("tab", "shift+tab"),
-		key.WithHelp("tab/shift+tab", "switch input"),
-	),
-	Arrow: key.NewBinding(
-		key.WithKeys("right", "left"),
-		key.WithHelp("→/←", "change option"),
-	),
-}
+var addTokenKeys addTokenKeyMap
 
 // Add token ascii
 var addTokenAscii = `
@@ -75,19 +61,32 @@ type AddTokenScreen struct {
 
 	// Viewport content
 	content string
+
+	// Context
+	context *context.Context
 }
 
 // Initializes a new screen of AddTokenScreen
-func InitializeAddTokenScreen(folder tlockvault.Folder, vault *tlockvault.Vault) AddTokenScreen {
+func InitializeAddTokenScreen(folder tlockvault.Folder, vault *tlockvault.Vault, context *context.Context) AddTokenScreen {
+	// Initialize keys
+	addTokenKeys = addTokenKeyMap{
+		Enter:  context.Config.Navigation.Confirm.WithHelp("create token"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+		Tab:    config.Combine("switch input", context.Config.Navigation.NextInput, context.Config.Navigation.PreviousInput),
+		Arrow:  config.Combine("change option", context.Config.Navigation.OptionRight, context.Config.Navigation.OptionLeft),
+		Icons:  context.Config.Navigation.BrowseIcons.WithHelp("browse icons"),
+	}
+
 	// Initialize form
-	form := BuildForm(map[string]string{})
+	form := BuildForm(map[string]string{}, formKeys(context), context.Icons)
 
 	// Return
 	return AddTokenScreen{
 		form:     form,
 		vault:    vault,
 		folder:   folder,
-		viewport: IntoViewport(addTokenAscii, addTokenDesc, form),
+		context:  context,
+		viewport: IntoViewport(addTokenAscii, addTokenDesc, form, addTokenKeys, vault),
 	}
 }
 
@@ -107,8 +106,14 @@ func (screen AddTokenScreen) Update(msg tea.Msg, manager *modelmanager.ModelMana
 		switch {
 		case key.Matches(msgType, addTokenKeys.GoBack):
 			manager.PopScreen()
+
+		case key.Matches(msgType, addTokenKeys.Icons):
+			cmds = append(cmds, manager.PushScreen(InitializeIconBrowserScreen(screen.form.Item("icon").Value(), screen.context)))
 		}
 
+	case IconChosenMsg:
+		screen.form.SetValue("icon", msgType.Name)
+
 	case form.FormSubmittedMsg:
 		// Get token
 		token := TokenFromFormData(msgType.Data)
@@ -139,8 +144,9 @@ func (screen AddTokenScreen) Update(msg tea.Msg, manager *modelmanager.ModelMana
 	cmds = append(cmds, screen.form.Update(msg, screen.vault))
 
 	// Update the viewport
+	FillFromURI(&screen.form)
 	DisableBasedOnType(&screen.form)
-	UpdateViewport(addTokenAscii, addTokenDesc, &screen.viewport, screen.form)
+	UpdateViewport(addTokenAscii, addTokenDesc, &screen.viewport, screen.form, addTokenKeys, screen.vault)
 
 	// Send the update message to the viewport
 	screen.viewport, _ = screen.viewport.Update(msg)
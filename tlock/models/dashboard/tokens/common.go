@@ -2,13 +2,17 @@ package tokens
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
 	tlockform "github.com/eklairs/tlock/tlock-internal/form"
 	"github.com/eklairs/tlock/tlock-internal/utils"
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
@@ -57,10 +61,21 @@ func secretValidator(vault *tlockvault.Vault, secret string) error {
 	return err
 }
 
+// Builds the keys of the forms from the config
+func formKeys(context *context.Context) tlockform.KeyMap {
+	return tlockform.KeyMap{
+		Next:     context.Config.Navigation.NextInput.Binding,
+		Previous: context.Config.Navigation.PreviousInput.Binding,
+		Submit:   context.Config.Navigation.Confirm.Binding,
+		Left:     context.Config.Navigation.OptionLeft.Binding,
+		Right:    context.Config.Navigation.OptionRight.Binding,
+	}
+}
+
 // Returns the form
-func BuildForm(values map[string]string) tlockform.Form {
+func BuildForm(values map[string]string, keys tlockform.KeyMap, icons context.IconSet) tlockform.Form {
 	// Initialize form
-	form := tlockform.New()
+	form := tlockform.New(keys)
 
 	// Sets the value of the input box
 	v := func(input textinput.Model, key string) textinput.Model {
@@ -74,12 +89,14 @@ func BuildForm(values map[string]string) tlockform.Form {
 	// Add items
 	form.AddInput("account", "Account Name", "Name of the account, like John Doe", v(components.InitializeInputBox("Account name goes here..."), "account"), []tlockform.Validator{})
 	form.AddInput("issuer", "Issuer", "Name of the issuer, like GitHub", v(components.InitializeInputBox("Issuer name goes here..."), "issuer"), []tlockform.Validator{})
-	form.AddInput("secret", "Secret", "The secret provided by the issuer", v(components.InitializeInputBox("The secret goes here..."), "secret"), []tlockform.Validator{secretValidator})
+	form.AddInput("secret", "Secret", "The secret provided by the issuer, or paste its otpauth:// URI", v(components.InitializeInputBox("The secret goes here..."), "secret"), []tlockform.Validator{secretValidator})
 	form.AddOption("type", "Type", "Type of the token", []string{"TOTP", "HOTP"})
 	form.AddOption("hash", "Hash", "Hashing algorithm for the token", []string{"SHA1", "SHA256", "SHA512"})
 	form.AddInput("period", "Period", "Time to refresh the token", v(onlyInt(components.InitializeInputBoxCustomWidth("Time in seconds...", 24)), "period"), []tlockform.Validator{periodValidator})
 	form.AddInput("counter", "Initial counter", "Initial counter for HOTP token", v(onlyInt(components.InitializeInputBoxCustomWidth("Initial counter...", 24)), "counter"), []tlockform.Validator{})
 	form.AddInput("digits", "Digits", "Number of digits", v(onlyInt(components.InitializeInputBoxCustomWidth("Number of digits goes here...", 24)), "digits"), []tlockform.Validator{digitValidator})
+	form.AddInput("tags", "Tags", "Comma separated tags, used by smart folders", v(components.InitializeInputBox("Like work, prod..."), "tags"), []tlockform.Validator{})
+	form.AddInput("icon", "Icon", "Name of the icon, found from the issuer if empty", v(components.InitializeInputBox("Like GitHub..."), "icon"), []tlockform.Validator{iconValidator(icons)})
 
 	// Set default values
 	form.Default = map[string]string{
@@ -100,8 +117,44 @@ func BuildForm(values map[string]string) tlockform.Form {
 	return form
 }
 
+// Width of the code preview
+const previewWidth = 72
+
+// Renders the code which the values of the form produce, to compare it with the issuer before saving
+func RenderPreview(form tlockform.Form, vault *tlockvault.Vault) string {
+	items := []string{
+		tlockstyles.Styles.Title.Render("Preview"),
+		tlockstyles.Styles.SubText.Render("Code from the values above, which should match the one of the issuer"), "",
+	}
+
+	data, err := form.Validate(vault)
+
+	if err != nil {
+		items = append(items, tlockstyles.Styles.Error.Render(fmt.Sprintf("× %s", err)))
+	} else {
+		token := TokenFromFormData(data)
+		code := tlockstyles.Styles.Title.Render(strings.Join(strings.Split(token.CurrentCode(), ""), " "))
+
+		if token.Type == tlockvault.TokenTypeHOTP {
+			items = append(items, lipgloss.JoinHorizontal(lipgloss.Left, code, tlockstyles.Styles.SubText.Render(fmt.Sprintf("   at counter %d", token.InitialCounter))))
+		} else {
+			remaining := token.RemainingTime()
+			bar := strings.Repeat("▁", (previewWidth-24)*remaining/token.Period)
+
+			items = append(items, lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				code,
+				tlockstyles.Styles.SubText.Render(fmt.Sprintf("   %3ds  ", remaining)),
+				tlockstyles.Styles.Title.Render(bar),
+			))
+		}
+	}
+
+	return lipgloss.NewStyle().Width(previewWidth).Render(lipgloss.JoinVertical(lipgloss.Left, items...))
+}
+
 // Renders the form
-func RenderForm(ascii, description string, form tlockform.Form) string {
+func RenderForm(ascii, description string, form tlockform.Form, keys help.KeyMap, vault *tlockvault.Vault) string {
 	// Items
 	items := []string{
 		tlockstyles.Styles.Title.Render(ascii), "",
@@ -131,20 +184,20 @@ func RenderForm(ascii, description string, form tlockform.Form) string {
 		)
 	}
 
-	// Add the help menu
-	items = append(items, inputGroup, "", tlockstyles.Help.View(addTokenKeys))
+	// Add the tags, the icon, the preview and the help menu
+	items = append(items, inputGroup, "", form.Item("tags").View(), form.Item("icon").View(), RenderPreview(form, vault), "", tlockstyles.Help.View(keys))
 
 	// Return
 	return lipgloss.JoinVertical(lipgloss.Center, items...)
 }
 
 // Creates a new viewport with the form rendered
-func IntoViewport(ascii, description string, form tlockform.Form) viewport.Model {
+func IntoViewport(ascii, description string, form tlockform.Form, keys help.KeyMap, vault *tlockvault.Vault) viewport.Model {
 	// Get size
 	_, height, _ := term.GetSize(int(os.Stdout.Fd()))
 
 	// Rendered content
-	content := RenderForm(ascii, description, form)
+	content := RenderForm(ascii, description, form, keys, vault)
 
 	// Initialize
 	viewport := utils.DisableViewportKeys(viewport.New(85, min(height, lipgloss.Height(content))))
@@ -155,12 +208,12 @@ func IntoViewport(ascii, description string, form tlockform.Form) viewport.Model
 }
 
 // Updates the viewport with the new form content
-func UpdateViewport(ascii, description string, viewport *viewport.Model, form tlockform.Form) {
+func UpdateViewport(ascii, description string, viewport *viewport.Model, form tlockform.Form, keys help.KeyMap, vault *tlockvault.Vault) {
 	// Get size
 	_, height, _ := term.GetSize(int(os.Stdout.Fd()))
 
 	// Rendered content
-	content := RenderForm(ascii, description, form)
+	content := RenderForm(ascii, description, form, keys, vault)
 
 	// Update
 	viewport.Height = min(height, lipgloss.Height(content))
@@ -181,6 +234,36 @@ func DisableBasedOnType(form *tlockform.Form) {
 	}
 }
 
+// Fills every input of the form from the otpauth URI pasted in the secret input
+func FillFromURI(form *tlockform.Form) {
+	uri := strings.TrimSpace(form.Items[2].FormItem.Value())
+
+	if !strings.HasPrefix(strings.ToLower(uri), "otpauth://") {
+		return
+	}
+
+	token, err := tlockvault.TokenFromURI(uri)
+
+	if err != nil {
+		err = fmt.Errorf("Invalid otpauth URI: %w", err)
+		form.Items[2].FormItem.SetError(&err)
+
+		return
+	}
+
+	form.Items[2].FormItem.SetError(nil)
+
+	// Fill
+	form.SetValue("account", token.Account)
+	form.SetValue("issuer", token.Issuer)
+	form.SetValue("secret", token.Secret)
+	form.SetValue("type", tokenTypeToString(token.Type))
+	form.SetValue("hash", hashAlgoToString(token.HashingAlgorithm))
+	form.SetValue("period", fmt.Sprint(token.Period))
+	form.SetValue("counter", fmt.Sprint(token.InitialCounter))
+	form.SetValue("digits", fmt.Sprint(token.Digits))
+}
+
 // Converts string to token type
 func toTokenType(tokentype string) tlockvault.TokenType {
 	if tokentype == "HOTP" {
@@ -213,5 +296,7 @@ func TokenFromFormData(data map[string]string) tlockvault.Token {
 		Period:           utils.ToInt(data["period"]),
 		Digits:           utils.ToInt(data["digits"]),
 		HashingAlgorithm: toOtpAlgorithm(data["hash"]),
+		Tags:             tlockvault.ParseTags(data["tags"]),
+		Icon:             data["icon"],
 	}
 }
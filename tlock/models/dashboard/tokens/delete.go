@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
@@ -37,35 +38,28 @@ func (k deleteTokenKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var deleteTokenKeys = deleteTokenKeyMap{
-	Delete: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "delete"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var deleteTokenKeys deleteTokenKeyMap
 
 // Delete token screen
 type DeleteTokenScreen struct {
 	// Vault
 	vault *tlockvault.Vault
 
-	// Token to delete
-	token tlockvault.Token
-
-	// Folder in which the token is
-	folder tlockvault.Folder
+	// Tokens to delete
+	tokens []tlockvault.Token
 }
 
 // Initialize root model
-func InitializeDeleteTokenScreen(vault *tlockvault.Vault, folder tlockvault.Folder, token tlockvault.Token) DeleteTokenScreen {
+func InitializeDeleteTokenScreen(vault *tlockvault.Vault, tokens []tlockvault.Token, context *context.Context) DeleteTokenScreen {
+	// Initialize keys
+	deleteTokenKeys = deleteTokenKeyMap{
+		Delete: context.Config.Navigation.Confirm.WithHelp("delete"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	// Return
 	return DeleteTokenScreen{
-		folder: folder,
-		token:  token,
+		tokens: tokens,
 		vault:  vault,
 	}
 }
@@ -86,21 +80,28 @@ func (screen DeleteTokenScreen) Update(msg tea.Msg, manager *modelmanager.ModelM
 			manager.PopScreen()
 		case key.Matches(msgType, deleteTokenKeys.Delete):
 			// Delete
-			screen.vault.DeleteToken(screen.folder.Name, screen.token)
+			screen.vault.DeleteTokens(screen.tokens)
+
+			message := fmt.Sprintf("Successfully deleted %d tokens", len(screen.tokens))
 
-			accountName := screen.token.Account
+			if len(screen.tokens) == 1 {
+				accountName := screen.tokens[0].Account
 
-			if accountName == "" {
-				accountName = "<no account name>"
+				if accountName == "" {
+					accountName = "<no account name>"
+				}
+
+				message = fmt.Sprintf("Successfully deleted the token (%s)", accountName)
 			}
 
 			// Require refresh of folders and tokens list
 			cmds = append(
 				cmds,
+				func() tea.Msg { return tlockmessages.ClearSelectionMsg{} },
 				func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
 				func() tea.Msg { return tlockmessages.RefreshTokensMsg{} },
 				func() tea.Msg {
-					return components.StatusBarMsg{Message: fmt.Sprintf("Successfully deleted the token (%s)", accountName)}
+					return components.StatusBarMsg{Message: message}
 				},
 			)
 
@@ -114,16 +115,22 @@ func (screen DeleteTokenScreen) Update(msg tea.Msg, manager *modelmanager.ModelM
 
 // View
 func (screen DeleteTokenScreen) View() string {
+	desc, name, suffix := "Move the token to the trash", screen.tokens[0].Account, " token ?"
+
+	if len(screen.tokens) > 1 {
+		desc, name, suffix = "Move the selected tokens to the trash", fmt.Sprint(len(screen.tokens)), " tokens ?"
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
 		tlockstyles.Styles.Title.Render(deleteTokenAsciiArt), "",
-		tlockstyles.Styles.SubText.Render("Permanently delete token"), "",
+		tlockstyles.Styles.SubText.Render(desc), "",
 		lipgloss.JoinHorizontal(
 			lipgloss.Center,
 			tlockstyles.Styles.SubText.Render("Are you sure you want to "),
 			tlockstyles.Styles.Error.Render("× DELETE "),
-			tlockstyles.Styles.Title.Render(screen.token.Account),
-			tlockstyles.Styles.SubText.Render(" token ?"),
+			tlockstyles.Styles.Title.Render(name),
+			tlockstyles.Styles.SubText.Render(suffix),
 		), "",
 		tlockstyles.Help.View(deleteTokenKeys),
 	)
@@ -0,0 +1,346 @@
+package tokens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/clipboard"
+	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+	"github.com/mattn/go-runewidth"
+)
+
+// Width of the details
+const detailsWidth = 75
+
+// Width of the names of the fields
+const detailsLabelWidth = 18
+
+var detailsAscii = `
+█ █▄ █ █▀▀ █▀█
+█ █ ▀█ █▀  █▄█`
+
+// Single field of the token
+type detailsRow struct {
+	// Name of the field
+	Label string
+
+	// Shown value
+	Value string
+
+	// Value which is copied
+	Copy string
+
+	// Whether copying it uses the code, which is recorded in the usage of the token
+	IsCode bool
+}
+
+// Token details key map
+type tokenDetailsKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Copy   key.Binding
+	Reveal key.Binding
+	GoBack key.Binding
+}
+
+// ShortHelp()
+func (k tokenDetailsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Copy, k.Reveal, k.GoBack}
+}
+
+// FullHelp()
+func (k tokenDetailsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{}
+}
+
+// Keys
+var tokenDetailsKeys tokenDetailsKeyMap
+
+// Token details screen
+type TokenDetailsScreen struct {
+	// Vault
+	vault *tlockvault.Vault
+
+	// Context
+	context *context.Context
+
+	// Token
+	token tlockvault.Token
+
+	// Whether the codes are shown, as they are in the tokens list
+	showCodes bool
+
+	// Whether the secret and the codes are revealed
+	revealed bool
+
+	// Focused field
+	focused int
+
+	// Result of the last copy, the status bar is not shown on this screen
+	status *components.StatusBarMsg
+}
+
+// Initializes a new instance of the token details screen
+func InitializeTokenDetailsScreen(vault *tlockvault.Vault, token tlockvault.Token, showCodes bool, context *context.Context) TokenDetailsScreen {
+	// Initialize keys
+	tokenDetailsKeys = tokenDetailsKeyMap{
+		Up:     context.Config.Navigation.Up.WithHelp("move up"),
+		Down:   context.Config.Navigation.Down.WithHelp("move down"),
+		Copy:   key.NewBinding(key.WithKeys(append(context.Config.Navigation.Confirm.Keys(), context.Config.Tokens.Copy.Keys()...)...), key.WithHelp(context.Config.Tokens.Copy.HelpKeys(), "copy")),
+		Reveal: context.Config.Tokens.Reveal.WithHelp("reveal secret"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
+	return TokenDetailsScreen{
+		vault:     vault,
+		context:   context,
+		token:     token,
+		showCodes: showCodes,
+	}
+}
+
+// Returns the fields of the token
+func (screen TokenDetailsScreen) rows() []detailsRow {
+	token := screen.token
+
+	// Hides the value unless it is revealed
+	mask := func(value string, shown bool) string {
+		if shown || screen.revealed {
+			return value
+		}
+
+		return strings.Repeat("*", min(len(value), 24))
+	}
+
+	// Empty values
+	or := func(value, fallback string) string {
+		if value == "" {
+			return fallback
+		}
+
+		return value
+	}
+
+	rows := []detailsRow{
+		{Label: "Account", Value: or(token.Account, "<no account name>"), Copy: token.Account},
+		{Label: "Issuer", Value: or(token.Issuer, "<no issuer>"), Copy: token.Issuer},
+		{Label: "Folder", Value: screen.vault.FolderOf(token).Name, Copy: screen.vault.FolderOf(token).Name},
+		{Label: "Code", Value: mask(token.CurrentCode(), screen.showCodes), Copy: token.CurrentCode(), IsCode: true},
+		{Label: "Next code", Value: mask(token.NextCode(), screen.showCodes), Copy: token.NextCode()},
+		{Label: "Type", Value: tokenTypeToString(token.Type), Copy: tokenTypeToString(token.Type)},
+		{Label: "Algorithm", Value: hashAlgoToString(token.HashingAlgorithm), Copy: hashAlgoToString(token.HashingAlgorithm)},
+		{Label: "Digits", Value: fmt.Sprint(token.Digits), Copy: fmt.Sprint(token.Digits)},
+	}
+
+	if token.Type == tlockvault.TokenTypeHOTP {
+		counter := token.InitialCounter + token.UsageCounter
+
+		rows = append(rows, detailsRow{
+			Label: "Counter",
+			Value: fmt.Sprintf("%d (started at %d, used %d times)", counter, token.InitialCounter, token.UsageCounter),
+			Copy:  fmt.Sprint(counter),
+		})
+	} else {
+		rows = append(rows, detailsRow{Label: "Period", Value: fmt.Sprintf("%d seconds", token.Period), Copy: fmt.Sprint(token.Period)})
+	}
+
+	// The icon which is shown for the token
+	icon := "None"
+
+	if token.Icon != "" {
+		icon = token.Icon
+	} else if name, ok := screen.context.Icons.Find(token.Issuer); ok {
+		icon = fmt.Sprintf("%s (from the issuer)", name)
+	}
+
+	// Usage
+	lastUsed := "Never"
+
+	if !token.LastUsed.IsZero() {
+		lastUsed = token.LastUsed.Local().Format("2 January, 2006 at 15:04")
+	}
+
+	favorite := "No"
+
+	if token.Favorite {
+		favorite = "Yes"
+	}
+
+	copied := fmt.Sprintf("%d times", token.CopyCount)
+
+	if token.CopyCount == 1 {
+		copied = "Once"
+	}
+
+	tags := strings.Join(token.Tags, ", ")
+
+	rows = append(rows,
+		detailsRow{Label: "Tags", Value: or(tags, "<no tags>"), Copy: tags},
+		detailsRow{Label: "Favorite", Value: favorite, Copy: favorite},
+		detailsRow{Label: "Icon", Value: icon, Copy: token.Icon},
+		detailsRow{Label: "Copied", Value: copied, Copy: fmt.Sprint(token.CopyCount)},
+		detailsRow{Label: "Last used", Value: lastUsed, Copy: lastUsed},
+		detailsRow{Label: "ID", Value: token.ID(), Copy: token.ID()},
+		detailsRow{Label: "Secret", Value: mask(token.Secret, false), Copy: token.Secret},
+		detailsRow{Label: "URI", Value: mask(token.URI(), false), Copy: token.URI()},
+	)
+
+	return rows
+}
+
+// Init
+func (screen TokenDetailsScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update
+func (screen TokenDetailsScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		screen.status = nil
+
+		switch {
+		case key.Matches(msgType, tokenDetailsKeys.GoBack):
+			manager.PopScreen()
+
+		case key.Matches(msgType, tokenDetailsKeys.Up):
+			if screen.focused != 0 {
+				screen.focused -= 1
+			}
+
+		case key.Matches(msgType, tokenDetailsKeys.Down):
+			if screen.focused != len(screen.rows())-1 {
+				screen.focused += 1
+			}
+
+		case key.Matches(msgType, tokenDetailsKeys.Reveal):
+			screen.revealed = !screen.revealed
+
+		case key.Matches(msgType, tokenDetailsKeys.Copy):
+			cmds = append(cmds, screen.copy(screen.rows()[screen.focused]))
+		}
+
+	case components.StatusBarMsg:
+		screen.status = &msgType
+	}
+
+	return screen, tea.Batch(cmds...)
+}
+
+// Copies the value of the field
+func (screen *TokenDetailsScreen) copy(row detailsRow) tea.Cmd {
+	if row.Copy == "" {
+		return func() tea.Msg {
+			return components.StatusBarMsg{Message: fmt.Sprintf("%s is empty, nothing to copy", row.Label), ErrorMessage: true}
+		}
+	}
+
+	generation, err := clipboard.Write(row.Copy)
+
+	if err != nil {
+		return func() tea.Msg {
+			return components.StatusBarMsg{Message: err.Error(), ErrorMessage: true}
+		}
+	}
+
+	cmds := make([]tea.Cmd, 0)
+
+	// Used for sorting by usage
+	if row.IsCode {
+		screen.vault.MarkTokenUsed(screen.vault.FolderOf(screen.token).Name, screen.token)
+		screen.refresh()
+
+		cmds = append(cmds, func() tea.Msg { return tlockmessages.RefreshTokensMsg{} })
+	}
+
+	// Clear it after the timeout
+	timeout := screen.context.Config.ClipboardTimeout()
+	copied := tlockmessages.ClipboardCopiedMsg{}
+
+	if timeout > 0 {
+		copied.Deadline = time.Now().Add(timeout)
+	}
+
+	cmds = append(cmds, func() tea.Msg {
+		return components.StatusBarMsg{Message: fmt.Sprintf("Successfully copied the %s", strings.ToLower(row.Label))}
+	}, func() tea.Msg {
+		return copied
+	}, clipboard.ClearAfter(generation, timeout))
+
+	return tea.Batch(cmds...)
+}
+
+// Reads the token again from the vault, after its usage changed
+func (screen *TokenDetailsScreen) refresh() {
+	for _, token := range screen.vault.FolderOf(screen.token).Tokens {
+		if token.Secret == screen.token.Secret {
+			screen.token = token
+		}
+	}
+}
+
+// Renders a single field
+func renderDetailsRow(row detailsRow, focused bool) string {
+	value := row.Value
+
+	// Long values, like the URI, are cut by their width on the screen, as some characters take two cells
+	value = runewidth.Truncate(value, detailsWidth-detailsLabelWidth-2, "…")
+
+	line := fmt.Sprintf("%-*s%s", detailsLabelWidth, row.Label, value)
+
+	if focused {
+		return tlockstyles.Styles.AccentBgItem.Copy().Width(detailsWidth).Render(line)
+	}
+
+	return tlockstyles.Styles.SubText.Copy().Padding(0, 1).Width(detailsWidth).Render(line)
+}
+
+// View
+func (screen TokenDetailsScreen) View() string {
+	desc := screen.token.Account
+
+	if screen.token.Issuer != "" {
+		desc = fmt.Sprintf("%s • %s", screen.token.Account, screen.token.Issuer)
+	}
+
+	items := []string{
+		tlockstyles.Title(detailsAscii), "",
+		tlockstyles.Dimmed(desc), "",
+	}
+
+	for index, row := range screen.rows() {
+		items = append(items, renderDetailsRow(row, index == screen.focused))
+	}
+
+	// Progress of the period
+	if screen.token.Type == tlockvault.TokenTypeTOTP {
+		remaining := screen.token.RemainingTime()
+		bar := strings.Repeat("▁", (detailsWidth-12)*remaining/screen.token.Period)
+
+		items = append(items, "", lipgloss.NewStyle().Width(detailsWidth).Render(
+			lipgloss.JoinHorizontal(lipgloss.Left, tlockstyles.Dimmed(fmt.Sprintf("%3ds left  ", remaining)), tlockstyles.Title(bar)),
+		))
+	}
+
+	// Result of the last copy
+	if screen.status != nil && screen.status.ErrorMessage {
+		items = append(items, "", tlockstyles.Styles.Error.Render(screen.status.Message))
+	} else if screen.status != nil {
+		items = append(items, "", tlockstyles.Dimmed(screen.status.Message))
+	}
+
+	items = append(items, "", tlockstyles.HelpView(tokenDetailsKeys))
+
+	return lipgloss.JoinVertical(lipgloss.Center, items...)
+}
@@ -2,12 +2,15 @@ package tokens
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/config"
+	"github.com/eklairs/tlock/tlock-internal/context"
 	"github.com/eklairs/tlock/tlock-internal/form"
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
@@ -27,11 +30,12 @@ type editTokenKeyMap struct {
 	Enter  key.Binding
 	Tab    key.Binding
 	Arrow  key.Binding
+	Icons  key.Binding
 }
 
 // ShortHelp()
 func (k editTokenKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Tab, k.Arrow, k.Enter, k.GoBack}
+	return []key.Binding{k.Tab, k.Arrow, k.Icons, k.Enter, k.GoBack}
 }
 
 // FullHelp()
@@ -40,24 +44,7 @@ func (k editTokenKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var editTokenKeys = editTokenKeyMap{
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "edit token"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-	Tab: key.NewBinding(
-		key.WithKeys("tab", "shift+tab"),
-		key.WithHelp("tab/shift+tab", "switch input"),
-	),
-	Arrow: key.NewBinding(
-		key.WithKeys("right", "left"),
-		key.WithHelp("→/←", "change option"),
-	),
-}
+var editTokenKeys editTokenKeyMap
 
 // Edit token ascii art
 var editTokenAscii = `
@@ -86,6 +73,9 @@ type EditTokenScreen struct {
 
 	// Viewport content
 	content string
+
+	// Context
+	context *context.Context
 }
 
 func tokenTypeToString(tokentype tlockvault.TokenType) string {
@@ -108,7 +98,16 @@ func hashAlgoToString(hash otp.Algorithm) string {
 }
 
 // Initializes a new screen of EditTokenScreen
-func InitializeEditTokenScreen(folder tlockvault.Folder, token tlockvault.Token, vault *tlockvault.Vault) EditTokenScreen {
+func InitializeEditTokenScreen(folder tlockvault.Folder, token tlockvault.Token, vault *tlockvault.Vault, context *context.Context) EditTokenScreen {
+	// Initialize keys
+	editTokenKeys = editTokenKeyMap{
+		Enter:  context.Config.Navigation.Confirm.WithHelp("edit token"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+		Tab:    config.Combine("switch input", context.Config.Navigation.NextInput, context.Config.Navigation.PreviousInput),
+		Arrow:  config.Combine("change option", context.Config.Navigation.OptionRight, context.Config.Navigation.OptionLeft),
+		Icons:  context.Config.Navigation.BrowseIcons.WithHelp("browse icons"),
+	}
+
 	// Form
 	form := BuildForm(map[string]string{
 		"account": token.Account,
@@ -119,7 +118,9 @@ func InitializeEditTokenScreen(folder tlockvault.Folder, token tlockvault.Token,
 		"period":  fmt.Sprintf("%d", token.Period),
 		"digits":  fmt.Sprintf("%d", token.Digits),
 		"counter": fmt.Sprintf("%d", token.InitialCounter),
-	})
+		"tags":    strings.Join(token.Tags, ", "),
+		"icon":    token.Icon,
+	}, formKeys(context), context.Icons)
 
 	// Override the validator for edit screen for secret
 	form.Items[2].Validators = []func(vault *tlockvault.Vault, value string) error{
@@ -143,7 +144,8 @@ func InitializeEditTokenScreen(folder tlockvault.Folder, token tlockvault.Token,
 		token:    token,
 		vault:    vault,
 		folder:   folder,
-		viewport: IntoViewport(editTokenAscii, editTokenDesc, form),
+		context:  context,
+		viewport: IntoViewport(editTokenAscii, editTokenDesc, form, editTokenKeys, vault),
 	}
 }
 
@@ -161,10 +163,16 @@ func (screen EditTokenScreen) Update(msg tea.Msg, manager *modelmanager.ModelMan
 	switch msgType := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msgType, addTokenKeys.GoBack):
+		case key.Matches(msgType, editTokenKeys.GoBack):
 			manager.PopScreen()
+
+		case key.Matches(msgType, editTokenKeys.Icons):
+			cmds = append(cmds, manager.PushScreen(InitializeIconBrowserScreen(screen.form.Item("icon").Value(), screen.context)))
 		}
 
+	case IconChosenMsg:
+		screen.form.SetValue("icon", msgType.Name)
+
 	case form.FormSubmittedMsg:
 		// Get token
 		token := TokenFromFormData(msgType.Data)
@@ -195,8 +203,9 @@ func (screen EditTokenScreen) Update(msg tea.Msg, manager *modelmanager.ModelMan
 	cmds = append(cmds, screen.form.Update(msg, screen.vault))
 
 	// Update the viewport
+	FillFromURI(&screen.form)
 	DisableBasedOnType(&screen.form)
-	UpdateViewport(editTokenAscii, editTokenDesc, &screen.viewport, screen.form)
+	UpdateViewport(editTokenAscii, editTokenDesc, &screen.viewport, screen.form, editTokenKeys, screen.vault)
 
 	// Send the update message to the viewport
 	screen.viewport, _ = screen.viewport.Update(msg)
@@ -0,0 +1,142 @@
+package tokens
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+)
+
+var exportTokensAscii = `
+█▀▀ ▀▄▀ █▀█ █▀█ █▀█ ▀█▀
+██▄ █ █ █▀▀ █▄█ █▀▄  █ `
+
+// Export tokens key map
+type exportTokensKeyMap struct {
+	GoBack key.Binding
+	Enter  key.Binding
+}
+
+// ShortHelp()
+func (k exportTokensKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.GoBack, k.Enter}
+}
+
+// FullHelp()
+func (k exportTokensKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.GoBack},
+		{k.Enter},
+	}
+}
+
+// Keys
+var exportTokensKeys exportTokensKeyMap
+
+// Export tokens screen
+type ExportTokensScreen struct {
+	// Path input
+	path textinput.Model
+
+	// Error
+	errorMessage *error
+
+	// Tokens to export
+	tokens []tlockvault.Token
+}
+
+// Initialize export tokens screen
+func InitializeExportTokensScreen(tokens []tlockvault.Token, context *context.Context) ExportTokensScreen {
+	// Initialize keys
+	exportTokensKeys = exportTokensKeyMap{
+		Enter:  context.Config.Navigation.Confirm.WithHelp("export"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
+	// Initialize input box, with a file in the home directory
+	path := components.InitializeInputBox("Path of the file to write the tokens to...")
+	path.Focus()
+
+	if home, err := os.UserHomeDir(); err == nil {
+		path.SetValue(filepath.Join(home, "tlock-export.txt"))
+	}
+
+	// Return
+	return ExportTokensScreen{
+		path:   path,
+		tokens: tokens,
+	}
+}
+
+// Init
+func (screen ExportTokensScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update
+func (screen ExportTokensScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case strings.Contains(msgType.String(), "tab"):
+			// We ignore tabs (because of bubbletea issue in windows)
+		case key.Matches(msgType, exportTokensKeys.GoBack):
+			manager.PopScreen()
+
+		case key.Matches(msgType, exportTokensKeys.Enter):
+			// One URI per line
+			uris := make([]string, 0, len(screen.tokens))
+
+			for _, token := range screen.tokens {
+				uris = append(uris, token.URI()+"\n")
+			}
+
+			// The secrets are in plain text, so only the user can read the file
+			if err := os.WriteFile(screen.path.Value(), []byte(strings.Join(uris, "")), 0600); err != nil {
+				screen.errorMessage = &err
+				break
+			}
+
+			cmds = append(
+				cmds,
+				func() tea.Msg { return tlockmessages.ClearSelectionMsg{} },
+				func() tea.Msg {
+					return components.StatusBarMsg{Message: fmt.Sprintf("Successfully exported %d tokens to %s", len(screen.tokens), screen.path.Value())}
+				},
+			)
+
+			// Pop
+			manager.PopScreen()
+		default:
+			// Send the value to input box
+			screen.path, _ = screen.path.Update(msg)
+		}
+	}
+
+	// Return
+	return screen, tea.Batch(cmds...)
+}
+
+// View
+func (screen ExportTokensScreen) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		tlockstyles.Title(exportTokensAscii), "",
+		tlockstyles.Dimmed(fmt.Sprintf("Export %d tokens as otpauth URIs, their secrets are written in plain text", len(screen.tokens))), "",
+		components.InputGroup("File", "The file is replaced if it already exists", screen.errorMessage, screen.path),
+		tlockstyles.HelpView(exportTokensKeys),
+	)
+}
@@ -1,7 +1,12 @@
 package tokens
 
 import (
+	"errors"
 	"fmt"
+	"image"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,6 +15,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/config"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	"github.com/eklairs/tlock/tlock-internal/form"
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
@@ -38,13 +46,16 @@ var MeterV2 = spinner.Spinner{
 var dataFromScreenChan = make(chan *dataFromScreen)
 
 type dataFromScreen struct {
-	// The otp
+	// The otp, nil if the screen could not be scanned
 	Uri *string
 
 	// Any error from validators
 	Err error
 }
 
+// Error representing that the region does not overlap with any of the scanned displays
+var ERR_REGION_OUTSIDE = errors.New("The region is outside of the display")
+
 // Message stating that a data has been recved
 type dataRecievedMsg struct {
 	data *dataFromScreen
@@ -60,20 +71,30 @@ const (
 	stateConfirm
 )
 
+// Option value for scanning every display
+const allDisplays = "All"
+
+// Available delays before the screen is captured
+var captureDelays = []string{"None", "3s", "5s", "10s"}
+
 // From screen key map
 type fromScreenKeyMap struct {
 	GoBack key.Binding
 	Start  key.Binding
+	Tab    key.Binding
+	Arrow  key.Binding
 }
 
 // ShortHelp()
 func (k fromScreenKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.GoBack, k.Start}
+	return []key.Binding{k.Tab, k.Arrow, k.GoBack, k.Start}
 }
 
 // FullHelp()
 func (k fromScreenKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
+		{k.Tab},
+		{k.Arrow},
 		{k.GoBack},
 		{k.Start},
 	}
@@ -88,16 +109,7 @@ func pollDataFetched() tea.Cmd {
 }
 
 // Keys
-var fromScreenKeys = fromScreenKeyMap{
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-	Start: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "start"),
-	),
-}
+var fromScreenKeys fromScreenKeyMap
 
 // Confirm from screen keys
 type confirmScreenKeyMap struct {
@@ -117,20 +129,7 @@ func (k confirmScreenKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var confirmScreenKeys = confirmScreenKeyMap{
-	Continue: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "continue"),
-	),
-	Retake: key.NewBinding(
-		key.WithKeys("r"),
-		key.WithHelp("r", "retake"),
-	),
-	Escape: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var confirmScreenKeys confirmScreenKeyMap
 
 type TokenFromScreen struct {
 	// State
@@ -150,10 +149,152 @@ type TokenFromScreen struct {
 
 	// Status bar message to send
 	statusBarMessage string
+
+	// Form to choose the display, region and delay
+	form form.Form
+
+	// Time at which the current capture is taken
+	captureAt time.Time
+}
+
+// Validator for the region to scan
+func regionValidator(_ *tlockvault.Vault, value string) error {
+	_, err := parseRegion(value)
+
+	return err
+}
+
+// Parses the region in the form of `x, y, width, height`
+// Returns nil if the value is empty, which means the whole display
+func parseRegion(value string) (*image.Rectangle, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	// Split into its parts
+	parts := strings.Split(value, ",")
+
+	if len(parts) != 4 {
+		return nil, errors.New("Region must be in the form of x, y, width, height")
+	}
+
+	// Parse
+	values := make([]int, 4)
+
+	for index, part := range parts {
+		num, err := strconv.Atoi(strings.TrimSpace(part))
+
+		if err != nil || num < 0 {
+			return nil, errors.New("Region values must be non-negative integers")
+		}
+
+		values[index] = num
+	}
+
+	if values[2] == 0 || values[3] == 0 {
+		return nil, errors.New("Region width and height cannot be zero")
+	}
+
+	// Return
+	region := image.Rect(values[0], values[1], values[0]+values[2], values[1]+values[3])
+
+	return &region, nil
+}
+
+// Returns the list of displays that can be choosen
+func displayOptions() []string {
+	options := []string{allDisplays}
+
+	for index := 0; index < screenshot.NumActiveDisplays(); index++ {
+		options = append(options, strconv.Itoa(index+1))
+	}
+
+	return options
+}
+
+// Builds the form for choosing what to capture
+func buildFromScreenForm(keys form.KeyMap) form.Form {
+	// Initialize form
+	captureForm := form.New(keys)
+
+	// Add items
+	captureForm.AddOption("display", "Display", "Display to scan, 1 is the primary display", displayOptions())
+	captureForm.AddOption("delay", "Delay", "Time to bring the QRCode into view", captureDelays)
+	captureForm.AddInput("region", "Region", "x, y, width, height [optional]", components.InitializeInputBoxCustomWidth("Whole display...", 24), []form.Validator{regionValidator})
+
+	// Run post init hook
+	captureForm.PostInit()
+
+	// Return
+	return captureForm
+}
+
+// Captures the given displays and scans them for a QRCode with an otp uri
+// Returns nil if no token was found on any of the displays
+func scanDisplays(vault *tlockvault.Vault, displays []int, region *image.Rectangle) *dataFromScreen {
+	scanned := false
+
+	for _, display := range displays {
+		bounds := screenshot.GetDisplayBounds(display)
+
+		// Region is relative to the display
+		if region != nil {
+			bounds = region.Add(bounds.Min).Intersect(bounds)
+		}
+
+		if bounds.Empty() {
+			continue
+		}
+
+		scanned = true
+
+		if image, err := screenshot.CaptureRect(bounds); err == nil {
+			if bmp, err := gozxing.NewBinaryBitmapFromImage(image); err == nil {
+				qrReader := qrcode.NewQRCodeReader()
+
+				if result, err := qrReader.Decode(bmp, nil); err == nil {
+					uri := result.String()
+
+					// Try to parse
+					if key, err := otp.NewKeyFromURL(uri); err == nil {
+						// Run validator
+						_, err := vault.ValidateToken(key.Secret())
+
+						// Return
+						return &dataFromScreen{
+							Uri: &uri,
+							Err: err,
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// The region did not overlap with any of the displays
+	if !scanned && region != nil {
+		return &dataFromScreen{Err: ERR_REGION_OUTSIDE}
+	}
+
+	return nil
 }
 
 // Initializes a new instance of fromScreen from screen
-func InitializeTokenFromScreen(vault *tlockvault.Vault, folder tlockvault.Folder) TokenFromScreen {
+func InitializeTokenFromScreen(vault *tlockvault.Vault, folder tlockvault.Folder, context *context.Context) TokenFromScreen {
+	// Initialize keys
+	fromScreenKeys = fromScreenKeyMap{
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+		Start:  context.Config.Navigation.Confirm.WithHelp("start"),
+		Tab:    config.Combine("switch input", context.Config.Navigation.NextInput, context.Config.Navigation.PreviousInput),
+		Arrow:  config.Combine("change option", context.Config.Navigation.OptionRight, context.Config.Navigation.OptionLeft),
+	}
+
+	confirmScreenKeys = confirmScreenKeyMap{
+		Continue: context.Config.Navigation.Confirm.WithHelp("continue"),
+		Retake:   context.Config.FromScreen.Retake.WithHelp("retake"),
+		Escape:   context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
 	// Initialize spinner
 	s := spinner.New()
 	s.Spinner = MeterV2
@@ -165,6 +306,7 @@ func InitializeTokenFromScreen(vault *tlockvault.Vault, folder tlockvault.Folder
 		vault:   vault,
 		spinner: s,
 		folder:  folder,
+		form:    buildFromScreenForm(formKeys(context)),
 	}
 }
 
@@ -183,46 +325,15 @@ func (screen TokenFromScreen) Update(msg tea.Msg, manager *modelmanager.ModelMan
 		case key.Matches(msgType, fromScreenKeys.GoBack):
 			manager.PopScreen()
 
-		case key.Matches(msgType, fromScreenKeys.Start) && screen.state == stateTake:
-			screen.state = stateGathering
+		case key.Matches(msgType, confirmScreenKeys.Retake) && screen.state == stateConfirm:
+			// Set state
+			screen.state = stateTake
 
-			// Start spinner
-			cmds = append(cmds, screen.spinner.Tick)
+			// Restart poll
+			cmds = append(cmds, pollDataFetched())
 
-			go func() {
-				if image, err := screenshot.CaptureRect(screenshot.GetDisplayBounds(0)); err == nil {
-					if bmp, err := gozxing.NewBinaryBitmapFromImage(image); err == nil {
-						qrReader := qrcode.NewQRCodeReader()
-
-						if result, err := qrReader.Decode(bmp, nil); err == nil {
-							uri := result.String()
-
-							// Try to parse
-							if key, err := otp.NewKeyFromURL(uri); err == nil {
-								// Run validator
-								_, err := screen.vault.ValidateToken(key.Secret())
-
-								// Send
-								dataFromScreenChan <- &dataFromScreen{
-									Uri: &uri,
-									Err: err,
-								}
-							}
-						}
-					}
-				}
-
-				dataFromScreenChan <- nil
-			}()
-
-		case key.Matches(msgType, confirmScreenKeys.Retake):
-			if screen.state == stateConfirm {
-				// Set state
-				screen.state = stateTake
-
-				// Restart poll
-				cmds = append(cmds, pollDataFetched())
-			}
+			// Do not let the form handle the key
+			return screen, tea.Batch(cmds...)
 
 		case key.Matches(msgType, confirmScreenKeys.Continue) && screen.state == stateConfirm:
 			// Add the token
@@ -242,11 +353,48 @@ func (screen TokenFromScreen) Update(msg tea.Msg, manager *modelmanager.ModelMan
 			manager.PopScreen()
 		}
 
+	case form.FormSubmittedMsg:
+		// Displays to scan
+		displays := []int{}
+
+		if index := slices.Index(displayOptions(), msgType.Data["display"]); index > 0 {
+			displays = append(displays, index-1)
+		} else {
+			for display := 0; display < screenshot.NumActiveDisplays(); display++ {
+				displays = append(displays, display)
+			}
+		}
+
+		// Delay before capturing
+		delay, _ := time.ParseDuration(msgType.Data["delay"])
+		screen.captureAt = time.Now().Add(delay)
+
+		// Region to capture, already validated by the form
+		region, _ := parseRegion(msgType.Data["region"])
+
+		screen.state = stateGathering
+
+		// Start spinner
+		cmds = append(cmds, screen.spinner.Tick)
+
+		go func(delay time.Duration) {
+			// Give time to bring the QRCode into view
+			time.Sleep(delay)
+
+			// Send
+			dataFromScreenChan <- scanDisplays(screen.vault, displays, region)
+		}(delay)
+
 	case dataRecievedMsg:
 		screen.token = msgType.data
 		screen.state = stateConfirm
 	}
 
+	// Let the form handle its update
+	if screen.state == stateTake {
+		cmds = append(cmds, screen.form.Update(msg, screen.vault))
+	}
+
 	if screen.state == stateGathering {
 		var cmd tea.Cmd
 		screen.spinner, cmd = screen.spinner.Update(msg)
@@ -263,16 +411,31 @@ func (screen TokenFromScreen) View() string {
 		return lipgloss.JoinVertical(
 			lipgloss.Center,
 			tlockstyles.Styles.Title.Render(fromScreenAsciiArt), "",
-			tlockstyles.Styles.SubText.Render("Place your QRCode window in the same screen as tlock"), "",
+			tlockstyles.Styles.SubText.Render("Choose the display where your QRCode window is"), "",
 			lipgloss.JoinHorizontal(
 				lipgloss.Center,
 				tlockstyles.Styles.MockScreen.Render("TLock"), "   ",
 				tlockstyles.Styles.MockScreen.Render("QRCode Window"),
 			), "",
+			screen.form.Items[0].FormItem.View(), "",
+			lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				screen.form.Items[1].FormItem.View(), "   ",
+				screen.form.Items[2].FormItem.View(),
+			),
 			tlockstyles.Help.View(fromScreenKeys),
 		)
 
 	case stateGathering:
+		// Show the remaining seconds if there is a delay, the spinner keeps redrawing it
+		if remaining := time.Until(screen.captureAt); remaining > 0 {
+			return lipgloss.JoinVertical(
+				lipgloss.Center,
+				screen.spinner.View(), "",
+				tlockstyles.Styles.SubText.Render(fmt.Sprintf("Capturing in %ds, bring the QRCode into view", int(math.Ceil(remaining.Seconds())))),
+			)
+		}
+
 		return screen.spinner.View()
 
 	case stateConfirm:
@@ -284,6 +447,9 @@ func (screen TokenFromScreen) View() string {
 		// If the token is null, show the message
 		if screen.token == nil {
 			items = append(items, tlockstyles.Styles.Error.Render("Did not find any token!"))
+		} else if screen.token.Uri == nil {
+			// The screen could not be scanned
+			items = append(items, tlockstyles.Styles.Error.Render(screen.token.Err.Error()))
 		} else {
 			// Try to parse the otp value
 			if screen.token.Err == nil {
@@ -0,0 +1,242 @@
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	"github.com/eklairs/tlock/tlock-internal/form"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+)
+
+// Error representing that the icon is not one of the known icons
+var ERR_UNKNOWN_ICON = errors.New("Unknown icon, browse the icons to choose one")
+
+// Message representing that an icon was chosen in the icon browser
+// An empty name means that the icon is found from the issuer
+type IconChosenMsg struct {
+	Name string
+}
+
+// Validator for the icon
+func iconValidator(icons context.IconSet) form.Validator {
+	return func(_ *tlockvault.Vault, icon string) error {
+		if _, ok := icons.Icons[icon]; icon != "" && !ok {
+			return ERR_UNKNOWN_ICON
+		}
+
+		return nil
+	}
+}
+
+// Icon list item
+type iconBrowserItem struct {
+	// Name of the icon, empty to find it from the issuer
+	Name string
+
+	// Icon
+	Icon context.Icon
+}
+
+// FilterValue()
+func (item iconBrowserItem) FilterValue() string {
+	return item.Name
+}
+
+// Icon list delegate
+type iconBrowserDelegate struct{}
+
+// Height
+func (d iconBrowserDelegate) Height() int {
+	return 3
+}
+
+// Spacing
+func (d iconBrowserDelegate) Spacing() int {
+	return 0
+}
+
+// Update
+func (d iconBrowserDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
+	return nil
+}
+
+// Render
+func (d iconBrowserDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item := listItem.(iconBrowserItem)
+
+	// Decide renderer function
+	render_fn := components.ListItemInactive
+
+	if index == m.Index() {
+		render_fn = components.ListItemActive
+	}
+
+	if item.Name == "" {
+		fmt.Fprint(w, render_fn(65, "Automatic", "from the issuer"))
+		return
+	}
+
+	fmt.Fprint(w, render_fn(65, item.Name, item.Icon.Unicode))
+}
+
+var iconBrowserAscii = `
+█ █▀▀ █▀█ █▄ █ █▀
+█ █▄▄ █▄█ █ ▀█ ▄█`
+
+// Icon browser key map
+type iconBrowserKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Choose key.Binding
+	GoBack key.Binding
+}
+
+// ShortHelp()
+func (k iconBrowserKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Choose, k.GoBack}
+}
+
+// FullHelp()
+func (k iconBrowserKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{}
+}
+
+// Keys
+var iconBrowserKeys iconBrowserKeyMap
+
+// Icon browser screen
+type IconBrowserScreen struct {
+	// Context
+	context *context.Context
+
+	// Search input
+	search textinput.Model
+
+	// Listview
+	listview list.Model
+}
+
+// Initializes a new instance of the icon browser, with the given icon focused
+func InitializeIconBrowserScreen(current string, context *context.Context) IconBrowserScreen {
+	// Initialize keys
+	iconBrowserKeys = iconBrowserKeyMap{
+		Up:     context.Config.Navigation.Up.WithHelp("move up"),
+		Down:   context.Config.Navigation.Down.WithHelp("move down"),
+		Choose: context.Config.Navigation.Confirm.WithHelp("choose"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
+	// Search input
+	search := components.InitializeInputBox("Search the icons...")
+	search.Focus()
+
+	// Initialize listview
+	listview := components.ListViewSimple([]list.Item{}, iconBrowserDelegate{}, 65, 15)
+	listview.KeyMap.CursorUp = context.Config.Navigation.Up.Binding
+	listview.KeyMap.CursorDown = context.Config.Navigation.Down.Binding
+
+	screen := IconBrowserScreen{
+		context:  context,
+		search:   search,
+		listview: listview,
+	}
+
+	screen.refresh()
+
+	// Focus the current icon
+	for index, item := range screen.listview.Items() {
+		if item.(iconBrowserItem).Name == current {
+			screen.listview.Select(index)
+		}
+	}
+
+	return screen
+}
+
+// Updates the icons based on the search
+func (screen *IconBrowserScreen) refresh() {
+	items := make([]list.Item, 0)
+
+	// Only offer the automatic icon without a search
+	if screen.search.Value() == "" {
+		items = append(items, iconBrowserItem{})
+	}
+
+	for _, name := range screen.context.Icons.Search(screen.search.Value()) {
+		items = append(items, iconBrowserItem{Name: name, Icon: screen.context.Icons.Icons[name]})
+	}
+
+	screen.listview.SetItems(items)
+	screen.listview.ResetSelected()
+}
+
+// Init
+func (screen IconBrowserScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update
+func (screen IconBrowserScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msgType, iconBrowserKeys.GoBack):
+			manager.PopScreen()
+
+		case key.Matches(msgType, iconBrowserKeys.Choose):
+			if item, ok := screen.listview.SelectedItem().(iconBrowserItem); ok {
+				cmds = append(cmds, func() tea.Msg { return IconChosenMsg{Name: item.Name} })
+
+				manager.PopScreen()
+			}
+
+		// Typed letters go to the search, even if they move around the list
+		case msgType.Type != tea.KeyRunes && key.Matches(msgType, iconBrowserKeys.Up, iconBrowserKeys.Down):
+			screen.listview, _ = screen.listview.Update(msg)
+
+		default:
+			previous := screen.search.Value()
+
+			var cmd tea.Cmd
+			screen.search, cmd = screen.search.Update(msg)
+			cmds = append(cmds, cmd)
+
+			if previous != screen.search.Value() {
+				screen.refresh()
+			}
+		}
+	}
+
+	return screen, tea.Batch(cmds...)
+}
+
+// View
+func (screen IconBrowserScreen) View() string {
+	items := []string{
+		tlockstyles.Title(iconBrowserAscii), "",
+		tlockstyles.Dimmed("Choose the icon of the token"), "",
+		components.InputGroup("Search", "Name of the icon or of the issuer, like AWS", nil, screen.search),
+	}
+
+	if len(screen.listview.Items()) == 0 {
+		items = append(items, tlockstyles.Dimmed("No icons match the search"), "")
+	} else {
+		items = append(items, screen.listview.View(), "", components.Paginator(screen.listview), "")
+	}
+
+	items = append(items, tlockstyles.HelpView(iconBrowserKeys))
+
+	return lipgloss.JoinVertical(lipgloss.Center, items...)
+}
@@ -3,12 +3,14 @@ package tokens
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
@@ -16,10 +18,10 @@ import (
 )
 
 // Folder list item
-type moveTokenListItem tlockvault.Folder
+type moveTokenListItem tlockvault.FolderNode
 
 func (item moveTokenListItem) FilterValue() string {
-	return item.Name
+	return item.Folder.Name
 }
 
 // Folder list view delegate
@@ -56,7 +58,7 @@ func (d moveTokenDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	}
 
 	// Render
-	fmt.Fprint(w, renderer(65, item.Name, "›"))
+	fmt.Fprint(w, renderer(65, strings.Repeat("  ", item.Depth)+item.Folder.Name, "›"))
 }
 
 var moveTokenAscii = `
@@ -83,44 +85,43 @@ func (k moveTokenKeyMap) FullHelp() [][]key.Binding {
 }
 
 // Keys
-var moveTokenKeys = moveTokenKeyMap{
-	Move: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "move"),
-	),
-	GoBack: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "go back"),
-	),
-}
+var moveTokenKeys moveTokenKeyMap
 
 type MoveTokenScreen struct {
 	// Vault
 	vault *tlockvault.Vault
 
-	// Token to move
-	token tlockvault.Token
-
-	// Folder
-	folder tlockvault.Folder
+	// Tokens to move
+	tokens []tlockvault.Token
 
 	// Listview
 	listview list.Model
 }
 
 // Initialize root model
-func InitializeMoveTokenScreen(vault *tlockvault.Vault, folder tlockvault.Folder, token tlockvault.Token) MoveTokenScreen {
-	items := make([]list.Item, len(vault.Folders))
+func InitializeMoveTokenScreen(vault *tlockvault.Vault, tokens []tlockvault.Token, context *context.Context) MoveTokenScreen {
+	// Initialize keys
+	moveTokenKeys = moveTokenKeyMap{
+		Move:   context.Config.Navigation.Confirm.WithHelp("move"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
 
-	for index, folder := range vault.Folders {
-		items[index] = moveTokenListItem(folder)
+	// Subfolders are shown below their parent
+	items := make([]list.Item, 0, len(vault.Folders))
+
+	for _, node := range vault.FolderTree(nil) {
+		items = append(items, moveTokenListItem(node))
 	}
 
+	// Initialize listview
+	listview := components.ListViewSimple(items, moveTokenDelegate{}, 65, min(15, len(vault.Folders)*3))
+	listview.KeyMap.CursorUp = context.Config.Navigation.Up.Binding
+	listview.KeyMap.CursorDown = context.Config.Navigation.Down.Binding
+
 	return MoveTokenScreen{
 		vault:    vault,
-		token:    token,
-		folder:   folder,
-		listview: components.ListViewSimple(items, moveTokenDelegate{}, 65, min(15, len(vault.Folders)*3)),
+		tokens:   tokens,
+		listview: listview,
 	}
 }
 
@@ -142,22 +143,29 @@ func (screen MoveTokenScreen) Update(msg tea.Msg, manager *modelmanager.ModelMan
 		case key.Matches(msgType, moveTokenKeys.Move):
 			focusedFolder := screen.listview.Items()[screen.listview.Index()].(moveTokenListItem)
 
-			// Move token
-			screen.vault.MoveToken(screen.token, screen.folder.Name, focusedFolder.Name)
+			// Move tokens
+			screen.vault.MoveTokens(screen.tokens, focusedFolder.Folder.Name)
 
-			accountName := screen.token.Account
+			message := fmt.Sprintf("Successfully moved %d tokens to %s folder", len(screen.tokens), focusedFolder.Folder.Name)
 
-			if accountName == "" {
-				accountName = "no account name"
+			if len(screen.tokens) == 1 {
+				accountName := screen.tokens[0].Account
+
+				if accountName == "" {
+					accountName = "no account name"
+				}
+
+				message = fmt.Sprintf("Successfully moved token (%s) to %s folder", accountName, focusedFolder.Folder.Name)
 			}
 
 			// Require refresh of folders and tokens list
 			cmds = append(
 				cmds,
+				func() tea.Msg { return tlockmessages.ClearSelectionMsg{} },
 				func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
 				func() tea.Msg { return tlockmessages.RefreshTokensMsg{} },
 				func() tea.Msg {
-					return components.StatusBarMsg{Message: fmt.Sprintf("Successfully moved token (%s) to %s folder", accountName, focusedFolder.Name)}
+					return components.StatusBarMsg{Message: message}
 				},
 			)
 
@@ -173,10 +181,16 @@ func (screen MoveTokenScreen) Update(msg tea.Msg, manager *modelmanager.ModelMan
 
 // View
 func (screen MoveTokenScreen) View() string {
+	desc := "Select the folder to move the token to"
+
+	if len(screen.tokens) > 1 {
+		desc = fmt.Sprintf("Select the folder to move the %d tokens to", len(screen.tokens))
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
 		tlockstyles.Title(moveTokenAscii), "",
-		tlockstyles.Dimmed("Select the folder to move the token to"), "",
+		tlockstyles.Dimmed(desc), "",
 		screen.listview.View(), "",
 		tlockstyles.HelpView(moveTokenKeys),
 	)
@@ -0,0 +1,74 @@
+package tokens
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+)
+
+// Tokens marked for a bulk action
+// Shared between the tokens and the list delegate, so it outlives the listview
+type tokenSelection struct {
+	// IDs of the selected tokens
+	ids map[string]bool
+}
+
+// Returns true if the token is selected
+func (selection tokenSelection) IsSelected(token tlockvault.Token) bool {
+	return selection.ids[token.ID()]
+}
+
+// Returns the number of selected tokens
+func (selection tokenSelection) Count() int {
+	return len(selection.ids)
+}
+
+// Selects the token, or unselects it if it already is
+func (selection *tokenSelection) Toggle(token tlockvault.Token) {
+	if selection.ids == nil {
+		selection.ids = make(map[string]bool)
+	}
+
+	if selection.ids[token.ID()] {
+		delete(selection.ids, token.ID())
+	} else {
+		selection.ids[token.ID()] = true
+	}
+}
+
+// Selects all the tokens of the list
+func (selection *tokenSelection) SelectAll(items []list.Item) {
+	selection.ids = make(map[string]bool)
+
+	for _, item := range items {
+		selection.ids[item.(tokensListItem).Token.ID()] = true
+	}
+}
+
+// Unselects every token
+func (selection *tokenSelection) Clear() {
+	selection.ids = nil
+}
+
+// Returns the selected tokens of the list, in the order they are shown
+func (selection tokenSelection) Tokens(items []list.Item) []tlockvault.Token {
+	selected := make([]tlockvault.Token, 0)
+
+	for _, item := range items {
+		if token := item.(tokensListItem).Token; selection.ids[token.ID()] {
+			selected = append(selected, token)
+		}
+	}
+
+	return selected
+}
+
+// Unselects the tokens which are no longer in the list
+func (selection *tokenSelection) Prune(items []list.Item) {
+	kept := make(map[string]bool)
+
+	for _, token := range selection.Tokens(items) {
+		kept[token.ID()] = true
+	}
+
+	selection.ids = kept
+}
@@ -0,0 +1,144 @@
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+)
+
+var tagTokensAscii = `
+▀█▀ ▄▀█ █▀▀
+ █  █▀█ █▄█`
+
+// Error representing that no tag was entered
+var ERR_NO_TAGS = errors.New("Enter at least one tag")
+
+// Tag tokens key map
+type tagTokensKeyMap struct {
+	GoBack key.Binding
+	Enter  key.Binding
+}
+
+// ShortHelp()
+func (k tagTokensKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.GoBack, k.Enter}
+}
+
+// FullHelp()
+func (k tagTokensKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.GoBack},
+		{k.Enter},
+	}
+}
+
+// Keys
+var tagTokensKeys tagTokensKeyMap
+
+// Tag tokens screen
+type TagTokensScreen struct {
+	// Tags input
+	tags textinput.Model
+
+	// Error
+	errorMessage *error
+
+	// Tokens to tag
+	tokens []tlockvault.Token
+
+	// Vault
+	vault *tlockvault.Vault
+}
+
+// Initialize tag tokens screen
+func InitializeTagTokensScreen(vault *tlockvault.Vault, tokens []tlockvault.Token, context *context.Context) TagTokensScreen {
+	// Initialize keys
+	tagTokensKeys = tagTokensKeyMap{
+		Enter:  context.Config.Navigation.Confirm.WithHelp("add tags"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
+	// Initialize input box
+	tags := components.InitializeInputBox("Like work, prod...")
+	tags.Focus()
+
+	// Return
+	return TagTokensScreen{
+		tags:   tags,
+		tokens: tokens,
+		vault:  vault,
+	}
+}
+
+// Init
+func (screen TagTokensScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update
+func (screen TagTokensScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case strings.Contains(msgType.String(), "tab"):
+			// We ignore tabs (because of bubbletea issue in windows)
+		case key.Matches(msgType, tagTokensKeys.GoBack):
+			manager.PopScreen()
+
+		case key.Matches(msgType, tagTokensKeys.Enter):
+			tags := tlockvault.ParseTags(screen.tags.Value())
+
+			if len(tags) == 0 {
+				screen.errorMessage = &ERR_NO_TAGS
+				break
+			}
+
+			// Tag
+			screen.vault.TagTokens(screen.tokens, tags)
+
+			// Tags change the tokens shown in the smart folders
+			cmds = append(
+				cmds,
+				func() tea.Msg { return tlockmessages.ClearSelectionMsg{} },
+				func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+				func() tea.Msg { return tlockmessages.RefreshTokensMsg{} },
+				func() tea.Msg {
+					return components.StatusBarMsg{Message: fmt.Sprintf("Successfully tagged %d tokens with %s", len(screen.tokens), strings.Join(tags, ", "))}
+				},
+			)
+
+			// Pop
+			manager.PopScreen()
+		default:
+			// Send the value to input box
+			screen.tags, _ = screen.tags.Update(msg)
+		}
+	}
+
+	// Return
+	return screen, tea.Batch(cmds...)
+}
+
+// View
+func (screen TagTokensScreen) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		tlockstyles.Title(tagTokensAscii), "",
+		tlockstyles.Dimmed(fmt.Sprintf("Add tags to %d tokens, the tags they already have are kept", len(screen.tokens))), "",
+		components.InputGroup("Tags", "Comma separated tags, used by smart folders", screen.errorMessage, screen.tags),
+		tlockstyles.HelpView(tagTokensKeys),
+	)
+}
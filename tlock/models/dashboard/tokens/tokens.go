@@ -5,24 +5,23 @@ import (
 	"io"
 	"math"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/clipboard"
 	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/config"
 	"github.com/eklairs/tlock/tlock-internal/context"
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
 	"github.com/eklairs/tlock/tlock-internal/utils"
 	tlockvault "github.com/eklairs/tlock/tlock-vault"
 	tlockstyles "github.com/eklairs/tlock/tlock/styles"
-	"github.com/pquerna/otp"
-	"github.com/pquerna/otp/hotp"
-	"github.com/pquerna/otp/totp"
 	"golang.org/x/term"
 )
 
@@ -31,30 +30,8 @@ func tokensWidth(width int) int {
 	return width - int(math.Floor((1.0/5.0)*float64(width)))
 }
 
-// Returns the remaining time
-func getRemainingTime(token tlockvault.Token) int {
-	return int(token.Period - int(time.Now().Unix())%token.Period)
-}
-
-// Returns the current code
-func getCurrentCode(token tlockvault.Token) string {
-	var code string
-
-	if token.Type == tlockvault.TokenTypeTOTP {
-		code, _ = totp.GenerateCodeCustom(token.Secret, time.Now(), totp.ValidateOpts{
-			Period:    uint(token.Period),
-			Digits:    otp.Digits(token.Digits),
-			Algorithm: token.HashingAlgorithm,
-		})
-	} else {
-		code, _ = hotp.GenerateCodeCustom(token.Secret, uint64(token.UsageCounter+token.InitialCounter), hotp.ValidateOpts{
-			Digits:    otp.Digits(token.Digits),
-			Algorithm: token.HashingAlgorithm,
-		})
-	}
-
-	return code
-}
+// Minimum width of a card in the grid layout
+const gridCardWidth = 40
 
 // Token list item
 type tokensListItem struct {
@@ -77,12 +54,12 @@ func (item tokensListItem) FilterValue() string {
 func (item *tokensListItem) Refresh() {
 	// If the token is a totp, then update the time
 	if item.Token.Type == tlockvault.TokenTypeTOTP {
-		timeToRefresh := getRemainingTime(item.Token)
+		timeToRefresh := item.Token.RemainingTime()
 		item.time = &timeToRefresh
 	}
 
 	// Update current code
-	item.CurrentCode = getCurrentCode(item.Token)
+	item.CurrentCode = item.Token.CurrentCode()
 }
 
 // Initializes a new instance of the tokens list item
@@ -90,12 +67,12 @@ func InitializeTokenListItem(token tlockvault.Token) tokensListItem {
 	var ttr *int
 
 	if token.Type == tlockvault.TokenTypeTOTP {
-		timeToRefresh := getRemainingTime(token)
+		timeToRefresh := token.RemainingTime()
 		ttr = &timeToRefresh
 	}
 
 	return tokensListItem{
-		CurrentCode: getCurrentCode(token),
+		CurrentCode: token.CurrentCode(),
 		Token:       token,
 		time:        ttr,
 	}
@@ -133,10 +110,20 @@ var EmptyAsciiArt = `
 // Tokens list delegate
 type tokensListDelegate struct {
 	context *context.Context
+
+	// Which codes are shown
+	visibility *codeVisibility
+
+	// Which tokens are selected
+	selection *tokenSelection
 }
 
 // Height
 func (d tokensListDelegate) Height() int {
+	if d.context.CurrentLayout() == config.LayoutCompact {
+		return 1
+	}
+
 	return 3
 }
 
@@ -152,15 +139,11 @@ func (d tokensListDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
 
 // Render
 func (d tokensListDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
-	item := listItem.(tokensListItem)
-
-	// Decide renderer function
-	render_fn := components.TokenItemActive
-
-	if index != m.Index() {
-		render_fn = components.TokenItemInactive
-	}
+	fmt.Fprint(w, d.renderItem(listItem.(tokensListItem), m.Width(), index == m.Index()))
+}
 
+// Renders the token for the current layout in the given width
+func (d tokensListDelegate) renderItem(item tokensListItem, width int, focused bool) string {
 	// Account name
 	account := item.Token.Account
 
@@ -175,27 +158,57 @@ func (d tokensListDelegate) Render(w io.Writer, m list.Model, index int, listIte
 		issuer = "<no issuer name>"
 	}
 
+	// Mark the selected tokens
+	if d.selection.IsSelected(item.Token) {
+		account = "✓ " + account
+	}
+
 	// Suffix (current code)
 	codeToShow := item.CurrentCode
 
-	// If it is not the current focused index, we will hide the token
-	if index != m.Index() {
+	// Hide the code unless the visibility mode shows it
+	if !d.visibility.Shows(d.context.Config.CodeVisibility, item.Token, focused) {
 		// Hide it aywa with astrisk
 		codeToShow = strings.Repeat("*", item.Token.Digits)
 	}
 
 	var tokenRenderable string
 
-	icon, ok := d.context.Icons[item.Token.Issuer]
+	icon, ok := d.context.Icons.For(item.Token.Issuer, item.Token.Icon)
 
 	if ok {
 		tokenRenderable = lipgloss.NewStyle().Foreground(lipgloss.Color("#" + icon.Hex)).Bold(true).Render(icon.Unicode)
 	} else {
-		tokenRenderable = tlockstyles.Styles.Title.Render("")
+		tokenRenderable = tlockstyles.Styles.Title.Render("")
+	}
+
+	// One line per token
+	if d.context.CurrentLayout() == config.LayoutCompact {
+		render_fn := components.TokenRowActive
+
+		if !focused {
+			render_fn = components.TokenRowInactive
+		}
+
+		// Time left, only for totp tokens
+		timeLeft := ""
+
+		if item.time != nil {
+			timeLeft = fmt.Sprintf("%3ds", *item.time)
+		}
+
+		return render_fn(width-6, tokenRenderable, account, issuer, strings.Join(strings.Split(codeToShow, ""), " "), timeLeft, d.context.Config.EnableIcons)
+	}
+
+	// Decide renderer function
+	render_fn := components.TokenItemActive
+
+	if !focused {
+		render_fn = components.TokenItemInactive
 	}
 
 	// Render
-	fmt.Fprint(w, render_fn(m.Width()-9, tokenRenderable, account, issuer, strings.Join(strings.Split(codeToShow, ""), "   "), item.Token.Period, item.time, d.context.Config.EnableIcons))
+	return render_fn(width-9, tokenRenderable, account, issuer, strings.Join(strings.Split(codeToShow, ""), "   "), item.Token.Period, item.time, d.context.Config.EnableIcons)
 }
 
 // Tokens
@@ -211,6 +224,12 @@ type Tokens struct {
 
 	// Context
 	context *context.Context
+
+	// Which codes are shown
+	visibility *codeVisibility
+
+	// Tokens selected for a bulk action
+	selection *tokenSelection
 }
 
 // Returns the focused folder item
@@ -237,17 +256,26 @@ func buildTokensItems(tokens []tlockvault.Token) []list.Item {
 }
 
 // Builds the tokens list view
-func buildTokensListView(tokens []tlockvault.Token, context *context.Context) list.Model {
+func buildTokensListView(tokens []tlockvault.Token, delegate tokensListDelegate) list.Model {
 	// Get terminal size
 	width, height, _ := term.GetSize(int(os.Stdout.Fd()))
 
-	return components.ListViewSimple(buildTokensItems(tokens), tokensListDelegate{context: context}, tokensWidth(width), height-5)
+	listview := components.ListViewSimple(buildTokensItems(tokens), delegate, tokensWidth(width), height-5)
+
+	// Use the keys from the config for moving the focus
+	listview.KeyMap.CursorUp = delegate.context.Config.Tokens.Previous.Binding
+	listview.KeyMap.CursorDown = delegate.context.Config.Tokens.Next.Binding
+
+	// Unbind the paging keys (like f and d), they are used by the dashboard
+	listview.KeyMap.NextPage = key.NewBinding()
+	listview.KeyMap.PrevPage = key.NewBinding()
+
+	return listview
 }
 
-// Initializes a new instance of folders
-func InitializeTokens(vault *tlockvault.Vault, context *context.Context) Tokens {
-	// Initialize keys
-	tokenKeys = tokenKeyMap{
+// Builds the token keys from the config
+func buildTokenKeys(context *context.Context) tokenKeyMap {
+	return tokenKeyMap{
 		Manual: key.NewBinding(
 			key.WithKeys(context.Config.Tokens.Add.Keys()...),
 			key.WithHelp(strings.Join(context.Config.Tokens.Add.Keys(), "/"), "add token"),
@@ -257,11 +285,19 @@ func InitializeTokens(vault *tlockvault.Vault, context *context.Context) Tokens
 			key.WithHelp(strings.Join(context.Config.Tokens.AddScreen.Keys(), "/"), "add token from screen"),
 		),
 	}
+}
+
+// Initializes a new instance of folders
+func InitializeTokens(vault *tlockvault.Vault, context *context.Context) Tokens {
+	// Initialize keys
+	tokenKeys = buildTokenKeys(context)
 
 	return Tokens{
-		vault:   vault,
-		folder:  nil,
-		context: context,
+		vault:      vault,
+		folder:     nil,
+		context:    context,
+		visibility: &codeVisibility{},
+		selection:  &tokenSelection{},
 	}
 }
 
@@ -273,49 +309,109 @@ func (tokens *Tokens) Update(msg tea.Msg, manager *modelmanager.ModelManager) te
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msgType, tokens.context.Config.Tokens.Copy.Binding):
-			if clipboard.Unsupported {
-				cmds = append(cmds, func() tea.Msg {
-					return components.StatusBarMsg{Message: "Clipboard is not available", ErrorMessage: true}
-				})
-			} else {
-				if focused := tokens.Focused(); focused != nil {
-					// Set clipboard
-					clipboard.WriteAll(focused.CurrentCode)
-
-					accountName := focused.Token.Account
-
-					if accountName == "" {
-						accountName = "<no account name>"
-					}
+			if focused := tokens.Focused(); focused != nil {
+				// Set clipboard
+				generation, err := clipboard.Write(focused.CurrentCode)
 
+				if err != nil {
 					cmds = append(cmds, func() tea.Msg {
-						return components.StatusBarMsg{Message: fmt.Sprintf("Successfully copied token (%s)", accountName)}
+						return components.StatusBarMsg{Message: err.Error(), ErrorMessage: true}
 					})
+
+					break
+				}
+
+				accountName := focused.Token.Account
+
+				if accountName == "" {
+					accountName = "<no account name>"
+				}
+
+				// Used for sorting by usage
+				tokens.vault.MarkTokenUsed(tokens.vault.FolderOf(focused.Token).Name, focused.Token)
+
+				// Clear it after the timeout
+				timeout := tokens.context.Config.ClipboardTimeout()
+				copied := tlockmessages.ClipboardCopiedMsg{}
+
+				if timeout > 0 {
+					copied.Deadline = time.Now().Add(timeout)
 				}
 
+				cmds = append(cmds, func() tea.Msg {
+					return components.StatusBarMsg{Message: fmt.Sprintf("Successfully copied token (%s)", accountName)}
+				}, func() tea.Msg {
+					return copied
+				}, func() tea.Msg {
+					return tlockmessages.RefreshTokensMsg{}
+				}, clipboard.ClearAfter(generation, timeout))
 			}
 
 		case key.Matches(msgType, tokens.context.Config.Tokens.Add.Binding):
-			if tokens.folder != nil {
-				manager.PushScreen(InitializeAddTokenScreen(*tokens.folder, tokens.vault))
+			if tokens.folder != nil && tokens.folder.Virtual {
+				cmds = append(cmds, tokens.addDisabledMsg())
+			} else if tokens.folder != nil {
+				manager.PushScreen(InitializeAddTokenScreen(*tokens.folder, tokens.vault, tokens.context))
 			}
 
 		case key.Matches(msgType, tokens.context.Config.Tokens.Edit.Binding):
 			if focused := tokens.Focused(); focused != nil {
-				manager.PushScreen(InitializeEditTokenScreen(*tokens.folder, focused.Token, tokens.vault))
+				manager.PushScreen(InitializeEditTokenScreen(tokens.vault.FolderOf(focused.Token), focused.Token, tokens.vault, tokens.context))
 			}
 
 		case key.Matches(msgType, tokens.context.Config.Tokens.Move.Binding):
-			if focused := tokens.Focused(); focused != nil {
-				manager.PushScreen(InitializeMoveTokenScreen(tokens.vault, *tokens.folder, focused.Token))
+			if targets := tokens.targets(); len(targets) != 0 && tokens.folder.IsSmart() {
+				cmds = append(cmds, func() tea.Msg {
+					return components.StatusBarMsg{Message: "Tokens cannot be moved from a smart folder, open their folder instead", ErrorMessage: true}
+				})
+			} else if len(targets) != 0 {
+				manager.PushScreen(InitializeMoveTokenScreen(tokens.vault, targets, tokens.context))
 			}
 
 		case key.Matches(msgType, tokens.context.Config.Tokens.Delete.Binding):
+			if targets := tokens.targets(); len(targets) != 0 {
+				manager.PushScreen(InitializeDeleteTokenScreen(tokens.vault, targets, tokens.context))
+			}
+
+		case key.Matches(msgType, tokens.context.Config.Tokens.Tag.Binding):
+			if targets := tokens.targets(); len(targets) != 0 {
+				manager.PushScreen(InitializeTagTokensScreen(tokens.vault, targets, tokens.context))
+			}
+
+		case key.Matches(msgType, tokens.context.Config.Tokens.Export.Binding):
+			if targets := tokens.targets(); len(targets) != 0 {
+				manager.PushScreen(InitializeExportTokensScreen(targets, tokens.context))
+			}
+
+		case key.Matches(msgType, tokens.context.Config.Tokens.Details.Binding):
 			if focused := tokens.Focused(); focused != nil {
-				manager.PushScreen(InitializeDeleteTokenScreen(tokens.vault, *tokens.folder, focused.Token))
+				showCodes := tokens.visibility.Shows(tokens.context.Config.CodeVisibility, focused.Token, true)
+				manager.PushScreen(InitializeTokenDetailsScreen(tokens.vault, focused.Token, showCodes, tokens.context))
+			}
+
+		case key.Matches(msgType, tokens.context.Config.Tokens.Select.Binding):
+			if focused := tokens.Focused(); focused != nil {
+				tokens.selection.Toggle(focused.Token)
+			}
+
+		case key.Matches(msgType, tokens.context.Config.Tokens.SelectAll.Binding):
+			if tokens.listview == nil || len(tokens.listview.Items()) == 0 {
+				break
+			}
+
+			// Unselect them if all of them already are
+			if len(tokens.selection.Tokens(tokens.listview.Items())) == len(tokens.listview.Items()) {
+				tokens.selection.Clear()
+			} else {
+				tokens.selection.SelectAll(tokens.listview.Items())
 			}
 
 		case key.Matches(msgType, tokens.context.Config.Tokens.MoveDown.Binding):
+			if !tokens.canReorder() {
+				cmds = append(cmds, tokens.reorderDisabledMsg())
+				break
+			}
+
 			if focused := tokens.Focused(); focused != nil {
 				// Move token down
 				tokens.vault.MoveTokenDown(tokens.folder.Name, focused.Token)
@@ -338,6 +434,11 @@ func (tokens *Tokens) Update(msg tea.Msg, manager *modelmanager.ModelManager) te
 			}
 
 		case key.Matches(msgType, tokens.context.Config.Tokens.MoveUp.Binding):
+			if !tokens.canReorder() {
+				cmds = append(cmds, tokens.reorderDisabledMsg())
+				break
+			}
+
 			if focused := tokens.Focused(); focused != nil {
 				// Move token down
 				tokens.vault.MoveTokenUp(tokens.folder.Name, focused.Token)
@@ -362,7 +463,7 @@ func (tokens *Tokens) Update(msg tea.Msg, manager *modelmanager.ModelManager) te
 		case key.Matches(msgType, tokens.context.Config.Tokens.NextHOTP.Binding):
 			if focused := tokens.Focused(); focused != nil {
 				if focused.Token.Type == tlockvault.TokenTypeHOTP {
-					tokens.vault.IncreaseCounter(tokens.folder.Name, focused.Token)
+					tokens.vault.IncreaseCounter(tokens.vault.FolderOf(focused.Token).Name, focused.Token)
 
 					accountName := focused.Token.Account
 					if accountName == "" {
@@ -377,19 +478,114 @@ func (tokens *Tokens) Update(msg tea.Msg, manager *modelmanager.ModelManager) te
 				}
 			}
 
+		case key.Matches(msgType, tokens.context.Config.Tokens.Reveal.Binding):
+			focused := tokens.Focused()
+
+			// Codes are only revealed in the hidden mode
+			if focused == nil || tokens.context.Config.CodeVisibility != config.VisibilityHidden || tokens.visibility.ShowsAll(tokens.context.Config.CodeVisibility) {
+				break
+			}
+
+			// Pressing it again hides the code
+			if tokens.visibility.IsRevealed(focused.Token) {
+				tokens.visibility.Hide()
+				break
+			}
+
+			tokens.visibility.Reveal(focused.Token, tokens.context.Config.RevealDuration())
+
+			cmds = append(cmds, func() tea.Msg {
+				return components.StatusBarMsg{Message: fmt.Sprintf("Revealed the code for %d seconds", tokens.context.Config.RevealTimeout)}
+			})
+
+		case key.Matches(msgType, tokens.context.Config.Tokens.ShowAll.Binding):
+			tokens.visibility.showAll = !tokens.visibility.showAll
+			tokens.visibility.Hide()
+
+			message := "Hiding the codes"
+
+			if tokens.visibility.ShowsAll(tokens.context.Config.CodeVisibility) {
+				message = "Showing all the codes"
+			}
+
+			cmds = append(cmds, func() tea.Msg {
+				return components.StatusBarMsg{Message: message}
+			})
+
+		case key.Matches(msgType, tokens.context.Config.Tokens.Favorite.Binding):
+			if focused := tokens.Focused(); focused != nil {
+				favorite := tokens.vault.ToggleFavorite(tokens.vault.FolderOf(focused.Token).Name, focused.Token)
+
+				accountName := focused.Token.Account
+
+				if accountName == "" {
+					accountName = "<no account name>"
+				}
+
+				message := fmt.Sprintf("Added %s to the favorites", accountName)
+
+				if !favorite {
+					message = fmt.Sprintf("Removed %s from the favorites", accountName)
+				}
+
+				// Refresh, the number of favorites is shown in the folders
+				cmds = append(cmds, func() tea.Msg {
+					return tlockmessages.RefreshTokensMsg{}
+				}, func() tea.Msg {
+					return tlockmessages.RefreshFoldersMsg{}
+				}, func() tea.Msg {
+					return components.StatusBarMsg{Message: message}
+				})
+			}
+
+		case key.Matches(msgType, tokens.context.Config.Tokens.Sort.Binding):
+			if tokens.folder != nil && tokens.folder.Virtual {
+				cmds = append(cmds, tokens.reorderDisabledMsg())
+			} else if tokens.folder != nil {
+				// Switch to the next sort mode
+				mode := tokens.vault.SortModeOf(tokens.folder.Name).Next()
+				tokens.vault.SetSortMode(tokens.folder.Name, mode)
+
+				cmds = append(cmds, func() tea.Msg {
+					return tlockmessages.RefreshTokensMsg{}
+				}, func() tea.Msg {
+					return components.StatusBarMsg{Message: fmt.Sprintf("Sorted the tokens by %s", mode)}
+				})
+			}
+
+		case key.Matches(msgType, tokens.context.Config.Tokens.SwitchLayout.Binding):
+			// Switch to the next layout
+			layout := config.LAYOUTS[(slices.Index(config.LAYOUTS, tokens.context.CurrentLayout())+1)%len(config.LAYOUTS)]
+			tokens.context.SetLayout(layout)
+
+			// The height of the items depends on the layout
+			if tokens.listview != nil {
+				tokens.listview.SetDelegate(tokens.delegate())
+			}
+
+			cmds = append(cmds, func() tea.Msg {
+				return components.StatusBarMsg{Message: fmt.Sprintf("Switched to the %s layout", layout)}
+			})
+
 		case key.Matches(msgType, tokens.context.Config.Tokens.AddScreen.Binding):
-			if tokens.folder != nil {
-				cmds = append(cmds, manager.PushScreen(InitializeTokenFromScreen(tokens.vault, *tokens.folder)))
+			if tokens.folder != nil && tokens.folder.Virtual {
+				cmds = append(cmds, tokens.addDisabledMsg())
+			} else if tokens.folder != nil {
+				cmds = append(cmds, manager.PushScreen(InitializeTokenFromScreen(tokens.vault, *tokens.folder, tokens.context)))
 			}
 		}
 
 	case tlockmessages.FolderChanged:
+		tokens.folder = &msgType.Folder
+
+		// The selection does not carry over to another folder
+		tokens.selection.Clear()
+
 		// Build listview
-		listview := buildTokensListView(tokens.vault.GetTokens(msgType.Folder.Name), tokens.context)
+		listview := buildTokensListView(tokens.folderTokens(), tokens.delegate())
 
 		// Update listview
 		tokens.listview = &listview
-		tokens.folder = &msgType.Folder
 
 	case tlockmessages.RefreshTokensValue:
 		if tokens.listview != nil {
@@ -411,9 +607,31 @@ func (tokens *Tokens) Update(msg tea.Msg, manager *modelmanager.ModelManager) te
 			tokens.listview.SetHeight(msgType.Height - 5)
 		}
 
+	case tlockmessages.ConfigReloadedMsg:
+		// Rebuild keys
+		tokenKeys = buildTokenKeys(tokens.context)
+
+		if tokens.listview != nil {
+			tokens.listview.KeyMap.CursorUp = tokens.context.Config.Tokens.Previous.Binding
+			tokens.listview.KeyMap.CursorDown = tokens.context.Config.Tokens.Next.Binding
+		}
+
+	case tlockmessages.ClearSelectionMsg:
+		tokens.selection.Clear()
+
 	case tlockmessages.RefreshTokensMsg:
 		if tokens.folder != nil {
-			cmds = append(cmds, tokens.listview.SetItems(buildTokensItems(tokens.vault.GetTokens(tokens.folder.Name))))
+			focused := tokens.Focused()
+
+			cmds = append(cmds, tokens.listview.SetItems(buildTokensItems(tokens.folderTokens())))
+
+			// Unselect the tokens which are gone
+			tokens.selection.Prune(tokens.listview.Items())
+
+			// Keep the focus on the same token if the sorting moved it
+			if focused != nil && !tokens.canReorder() {
+				tokens.focusToken(focused.Token)
+			}
 		}
 	}
 
@@ -449,12 +667,144 @@ func (tokens Tokens) View() string {
 			tlockstyles.Help.View(tokenKeys),
 		)
 
+		// Virtual folders explain how their tokens are picked
+		if tokens.folder.IsSmart() {
+			ui = lipgloss.JoinVertical(
+				lipgloss.Center,
+				tlockstyles.Styles.Title.Render(EmptyAsciiArt),
+				tlockstyles.Styles.SubText.Render(fmt.Sprintf("No tokens match %s", tokens.folder.Query)),
+			)
+		} else if tokens.folder.Virtual {
+			ui = lipgloss.JoinVertical(
+				lipgloss.Center,
+				tlockstyles.Styles.Title.Render(EmptyAsciiArt),
+				tlockstyles.Styles.SubText.Render(fmt.Sprintf("No favorites yet! Press %s on a token to pin it here", strings.Join(tokens.context.Config.Tokens.Favorite.Keys(), "/"))),
+			)
+		}
+
 		return style.Render(ui)
 	}
 
+	// Render the tokens
+	tokensView := tokens.listview.View()
+
+	if tokens.context.CurrentLayout() == config.LayoutGrid {
+		tokensView = tokens.gridView()
+	}
+
+	// Header with the sort mode
+	header := tlockstyles.Styles.AccentBgItem.Render("TOKENS")
+
+	if tokens.folder.IsSmart() {
+		header = lipgloss.JoinHorizontal(lipgloss.Left, header, tlockstyles.Styles.SubTextItem.Render(tokens.folder.Query))
+	} else if mode := tokens.vault.SortModeOf(tokens.folder.Name); mode != tlockvault.SortManual {
+		header = lipgloss.JoinHorizontal(lipgloss.Left, header, tlockstyles.Styles.SubTextItem.Render(fmt.Sprintf("sorted by %s", mode)))
+	}
+
+	if selected := tokens.selection.Count(); selected != 0 {
+		header = lipgloss.JoinHorizontal(lipgloss.Left, header, tlockstyles.Styles.SubTextItem.Render(fmt.Sprintf("%d selected", selected)))
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left, "",
-		tlockstyles.Styles.AccentBgItem.Render("TOKENS"), "",
-		tokens.listview.View(),
+		header, "",
+		tokensView,
 	)
 }
+
+// Returns the tokens of the current folder in the order they are shown
+func (tokens Tokens) folderTokens() []tlockvault.Token {
+	if tokens.folder.Virtual {
+		return tokens.vault.QueryTokens(tokens.folder.Query)
+	}
+
+	return tokens.vault.SortedTokens(tokens.folder.Name)
+}
+
+// Returns the tokens to run an action on, which are the selected ones or else the focused one
+func (tokens Tokens) targets() []tlockvault.Token {
+	if tokens.listview == nil {
+		return nil
+	}
+
+	if selected := tokens.selection.Tokens(tokens.listview.Items()); len(selected) != 0 {
+		return selected
+	}
+
+	if focused := tokens.Focused(); focused != nil {
+		return []tlockvault.Token{focused.Token}
+	}
+
+	return nil
+}
+
+// Returns the delegate for the tokens list
+func (tokens Tokens) delegate() tokensListDelegate {
+	return tokensListDelegate{context: tokens.context, visibility: tokens.visibility, selection: tokens.selection}
+}
+
+// Returns true if the tokens can be reordered, which is only in the manual sort mode
+func (tokens Tokens) canReorder() bool {
+	return tokens.folder == nil || (!tokens.folder.Virtual && tokens.vault.SortModeOf(tokens.folder.Name) == tlockvault.SortManual)
+}
+
+// Status bar message for when the tokens cannot be reordered
+func (tokens Tokens) reorderDisabledMsg() tea.Cmd {
+	return func() tea.Msg {
+		if tokens.folder.Virtual {
+			return components.StatusBarMsg{Message: fmt.Sprintf("The tokens of %s are always in the order of their folders", tokens.folder.Name), ErrorMessage: true}
+		}
+
+		return components.StatusBarMsg{
+			Message:      fmt.Sprintf("Tokens are sorted by %s, switch to manual sorting to reorder them", tokens.vault.SortModeOf(tokens.folder.Name)),
+			ErrorMessage: true,
+		}
+	}
+}
+
+// Status bar message for when a token is added in a virtual folder
+func (tokens Tokens) addDisabledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return components.StatusBarMsg{Message: fmt.Sprintf("Tokens cannot be added to %s, add them to a folder instead", tokens.folder.Name), ErrorMessage: true}
+	}
+}
+
+// Moves the focus to the given token
+func (tokens *Tokens) focusToken(token tlockvault.Token) {
+	for index, item := range tokens.listview.Items() {
+		if item.(tokensListItem).Token.ID() == token.ID() {
+			tokens.listview.Select(index)
+			return
+		}
+	}
+}
+
+// Renders the tokens as cards in multiple columns
+func (tokens Tokens) gridView() string {
+	delegate := tokens.delegate()
+	items := tokens.listview.Items()
+
+	// Number of columns and rows that fit
+	columns := max(1, tokens.listview.Width()/gridCardWidth)
+	cellWidth := tokens.listview.Width() / columns
+	rowsPerPage := max(1, tokens.listview.Height()/delegate.Height())
+
+	// Show the page of rows with the focused token
+	firstRow := (tokens.listview.Index() / columns) / rowsPerPage * rowsPerPage
+
+	rows := make([]string, 0)
+
+	for row := firstRow; row < firstRow+rowsPerPage && row*columns < len(items); row++ {
+		cells := make([]string, 0)
+
+		for index := row * columns; index < min(len(items), (row+1)*columns); index++ {
+			card := delegate.renderItem(items[index].(tokensListItem), cellWidth, index == tokens.listview.Index())
+
+			cells = append(cells, lipgloss.NewStyle().Width(cellWidth).Render(card))
+		}
+
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
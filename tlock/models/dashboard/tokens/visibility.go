@@ -0,0 +1,57 @@
+package tokens
+
+import (
+	"time"
+
+	"github.com/eklairs/tlock/tlock-internal/config"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+)
+
+// Which codes are shown in the tokens list
+// Shared between the tokens and the list delegate, so it outlives the listview
+type codeVisibility struct {
+	// Whether the show all toggle is on
+	showAll bool
+
+	// ID of the revealed token
+	revealed string
+
+	// Time after which the revealed code is hidden again
+	revealedUntil time.Time
+}
+
+// Returns true if every code is shown, either by the mode or by the toggle
+func (visibility codeVisibility) ShowsAll(mode string) bool {
+	return (mode == config.VisibilityAll) != visibility.showAll
+}
+
+// Returns true if the code of the token is revealed
+func (visibility codeVisibility) IsRevealed(token tlockvault.Token) bool {
+	return visibility.revealed == token.ID() && time.Now().Before(visibility.revealedUntil)
+}
+
+// Returns true if the code of the token should be shown
+func (visibility codeVisibility) Shows(mode string, token tlockvault.Token, focused bool) bool {
+	if visibility.ShowsAll(mode) {
+		return true
+	}
+
+	// Only the revealed code is shown in the hidden mode
+	if mode == config.VisibilityHidden {
+		return focused && visibility.IsRevealed(token)
+	}
+
+	return focused
+}
+
+// Reveals the code of the token for the given duration
+func (visibility *codeVisibility) Reveal(token tlockvault.Token, duration time.Duration) {
+	visibility.revealed = token.ID()
+	visibility.revealedUntil = time.Now().Add(duration)
+}
+
+// Hides the revealed code
+func (visibility *codeVisibility) Hide() {
+	visibility.revealed = ""
+	visibility.revealedUntil = time.Time{}
+}
@@ -0,0 +1,128 @@
+package trash
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+)
+
+var purgeAsciiArt = `
+█▀█ █ █ █▀█ █▀▀ █▀▀
+█▀▀ █▄█ █▀▄ █▄█ ██▄`
+
+// Sent when items were permanently deleted from the trash
+type trashPurgedMsg struct{}
+
+// Purge key map
+type purgeKeyMap struct {
+	Delete key.Binding
+	GoBack key.Binding
+}
+
+// ShortHelp()
+func (k purgeKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.GoBack, k.Delete}
+}
+
+// FullHelp()
+func (k purgeKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.GoBack},
+		{k.Delete},
+	}
+}
+
+// Keys
+var purgeKeys purgeKeyMap
+
+// Screen to confirm permanently deleting an item of the trash, or every item
+type PurgeScreen struct {
+	// Vault
+	vault *tlockvault.Vault
+
+	// Item to delete, nil to empty the trash
+	item *trashListItem
+}
+
+// Initializes the purge screen, a nil item empties the trash
+func InitializePurgeScreen(vault *tlockvault.Vault, item *trashListItem, context *context.Context) PurgeScreen {
+	// Initialize keys
+	purgeKeys = purgeKeyMap{
+		Delete: context.Config.Navigation.Confirm.WithHelp("delete forever"),
+		GoBack: context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
+	return PurgeScreen{
+		vault: vault,
+		item:  item,
+	}
+}
+
+// Init
+func (screen PurgeScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update
+func (screen PurgeScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msgType, purgeKeys.GoBack):
+			manager.PopScreen()
+
+		case key.Matches(msgType, purgeKeys.Delete):
+			message := "Permanently deleted every item in the trash"
+
+			if screen.item != nil {
+				screen.vault.PurgeTrashItem(screen.item.index)
+				message = fmt.Sprintf("Permanently deleted %s", screen.item.item.Name())
+			} else {
+				screen.vault.EmptyTrash()
+			}
+
+			cmds = append(
+				cmds,
+				func() tea.Msg { return trashPurgedMsg{} },
+				func() tea.Msg { return components.StatusBarMsg{Message: message} },
+			)
+
+			// Pop
+			manager.PopScreen()
+		}
+	}
+
+	return screen, tea.Batch(cmds...)
+}
+
+// View
+func (screen PurgeScreen) View() string {
+	name := "every item in the trash"
+
+	if screen.item != nil {
+		name = screen.item.item.Name()
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		tlockstyles.Title(purgeAsciiArt), "",
+		tlockstyles.Dimmed("It cannot be restored or undone afterwards"), "",
+		lipgloss.JoinHorizontal(
+			lipgloss.Center,
+			tlockstyles.Dimmed("Are you sure you want to "),
+			tlockstyles.Styles.Error.Render("× DELETE "),
+			tlockstyles.Title(name),
+			tlockstyles.Dimmed(" forever?"),
+		), "",
+		tlockstyles.Help.View(purgeKeys),
+	)
+}
@@ -0,0 +1,211 @@
+package trash
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+)
+
+// Destination folder list item
+type restoreToListItem struct {
+	// Name of the folder, empty for the top level
+	name string
+
+	// Number of parents above the folder
+	depth int
+}
+
+func (item restoreToListItem) FilterValue() string {
+	return item.name
+}
+
+// Destination folder list view delegate
+type restoreToDelegate struct{}
+
+// Height
+func (delegate restoreToDelegate) Height() int {
+	return 3
+}
+
+// Spacing
+func (delegate restoreToDelegate) Spacing() int {
+	return 0
+}
+
+// Update
+func (d restoreToDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
+	return nil
+}
+
+// Render
+func (d restoreToDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(restoreToListItem)
+
+	if !ok {
+		return
+	}
+
+	// Decide the renderer based on focused index
+	renderer := components.ListItemInactive
+
+	if index == m.Index() {
+		renderer = components.ListItemActive
+	}
+
+	name := item.name
+
+	if name == "" {
+		name = "Top level"
+	}
+
+	// Render
+	fmt.Fprint(w, renderer(trashWidth, strings.Repeat("  ", item.depth)+name, "›"))
+}
+
+var restoreToAscii = `
+█▀█ █▀▀ █▀ ▀█▀ █▀█ █▀█ █▀▀
+█▀▄ ██▄ ▄█  █  █▄█ █▀▄ ██▄`
+
+// Restore to key map
+type restoreToKeyMap struct {
+	GoBack  key.Binding
+	Restore key.Binding
+}
+
+// ShortHelp()
+func (k restoreToKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.GoBack, k.Restore}
+}
+
+// FullHelp()
+func (k restoreToKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.GoBack},
+		{k.Restore},
+	}
+}
+
+// Keys
+var restoreToKeys restoreToKeyMap
+
+// Restore to another folder screen
+type RestoreToScreen struct {
+	// Vault
+	vault *tlockvault.Vault
+
+	// Item to restore
+	item trashListItem
+
+	// Listview
+	listview list.Model
+}
+
+// Initialize restore to screen
+func InitializeRestoreToScreen(vault *tlockvault.Vault, item trashListItem, context *context.Context) RestoreToScreen {
+	// Initialize keys
+	restoreToKeys = restoreToKeyMap{
+		Restore: context.Config.Navigation.Confirm.WithHelp("restore"),
+		GoBack:  context.Config.Navigation.GoBack.WithHelp("go back"),
+	}
+
+	// Folders can also be restored to the top level
+	items := make([]list.Item, 0, len(vault.Folders)+1)
+	depth := 0
+
+	if item.item.IsFolder() {
+		items = append(items, restoreToListItem{})
+		depth = 1
+	}
+
+	for _, node := range vault.FolderTree(nil) {
+		items = append(items, restoreToListItem{name: node.Folder.Name, depth: node.Depth + depth})
+	}
+
+	// Initialize listview
+	listview := components.ListViewSimple(items, restoreToDelegate{}, trashWidth, min(15, len(items)*3))
+	listview.KeyMap.CursorUp = context.Config.Navigation.Up.Binding
+	listview.KeyMap.CursorDown = context.Config.Navigation.Down.Binding
+
+	return RestoreToScreen{
+		vault:    vault,
+		item:     item,
+		listview: listview,
+	}
+}
+
+// Init
+func (screen RestoreToScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update
+func (screen RestoreToScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msgType, restoreToKeys.GoBack):
+			manager.PopScreen()
+
+		case key.Matches(msgType, restoreToKeys.Restore):
+			if len(screen.listview.Items()) == 0 {
+				break
+			}
+
+			to := screen.listview.Items()[screen.listview.Index()].(restoreToListItem).name
+
+			if err := screen.vault.RestoreTrashItem(screen.item.index, to); err != nil {
+				cmds = append(cmds, func() tea.Msg {
+					return components.StatusBarMsg{Message: err.Error(), ErrorMessage: true}
+				})
+			} else {
+				cmds = append(
+					cmds,
+					func() tea.Msg { return trashChangedMsg{} },
+					func() tea.Msg { return components.StatusBarMsg{Message: restoredMessage(screen.item.item, to)} },
+				)
+			}
+
+			// Pop
+			manager.PopScreen()
+		}
+	}
+
+	screen.listview, _ = screen.listview.Update(msg)
+
+	return screen, tea.Batch(cmds...)
+}
+
+// View
+func (screen RestoreToScreen) View() string {
+	desc := fmt.Sprintf("Select the folder to restore %s to", screen.item.item.Name())
+
+	if screen.item.item.IsFolder() {
+		desc = fmt.Sprintf("Select the folder to restore %s inside of", screen.item.item.Name())
+	}
+
+	content := screen.listview.View()
+
+	if len(screen.listview.Items()) == 0 {
+		content = tlockstyles.Dimmed("There are no folders, add one first")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		tlockstyles.Title(restoreToAscii), "",
+		tlockstyles.Dimmed(desc), "",
+		content, "",
+		tlockstyles.HelpView(restoreToKeys),
+	)
+}
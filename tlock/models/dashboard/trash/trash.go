@@ -0,0 +1,327 @@
+package trash
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eklairs/tlock/tlock-internal/components"
+	"github.com/eklairs/tlock/tlock-internal/context"
+	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
+	"github.com/eklairs/tlock/tlock-internal/modelmanager"
+	tlockvault "github.com/eklairs/tlock/tlock-vault"
+	tlockstyles "github.com/eklairs/tlock/tlock/styles"
+)
+
+var trashAscii = `
+▀█▀ █▀█ ▄▀█ █▀ █ █
+ █  █▀▄ █▀█ ▄█ █▀█`
+
+// Width of the trash list
+const trashWidth = 65
+
+// Sent when the trash was changed by another screen, like the restore one
+type trashChangedMsg struct{}
+
+// Trash list item
+type trashListItem struct {
+	// Item
+	item tlockvault.TrashItem
+
+	// Index in the trash of the vault
+	index int
+}
+
+func (item trashListItem) FilterValue() string {
+	return item.item.Name()
+}
+
+// Trash list view delegate
+type trashDelegate struct{}
+
+// Height
+func (delegate trashDelegate) Height() int {
+	return 3
+}
+
+// Spacing
+func (delegate trashDelegate) Spacing() int {
+	return 0
+}
+
+// Update
+func (d trashDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
+	return nil
+}
+
+// Render
+func (d trashDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(trashListItem)
+
+	if !ok {
+		return
+	}
+
+	// Decide the renderer based on focused index
+	renderer := components.InactiveTrashListItem
+
+	if index == m.Index() {
+		renderer = components.ActiveTrashListItem
+	}
+
+	fmt.Fprint(w, renderer(trashWidth, describeItem(item.item), itemDetails(item.item)))
+}
+
+// Returns what the item is, like `alice • GitHub` or `Work folder with 2 tokens`
+func describeItem(item tlockvault.TrashItem) string {
+	if item.IsFolder() {
+		return fmt.Sprintf("%s folder with %d tokens", item.Name(), len(item.Folder.Tokens))
+	}
+
+	if item.Token.Issuer == "" {
+		return item.Name()
+	}
+
+	return fmt.Sprintf("%s • %s", item.Name(), item.Token.Issuer)
+}
+
+// Returns where the item was deleted from and when
+func itemDetails(item tlockvault.TrashItem) string {
+	from := item.From
+
+	if from == "" {
+		from = "the top level"
+	}
+
+	return fmt.Sprintf("from %s · deleted %s", from, item.DeletedAt.Local().Format("2 January, 2006 at 15:04"))
+}
+
+// Trash key map
+type trashKeyMap struct {
+	GoBack    key.Binding
+	Restore   key.Binding
+	RestoreTo key.Binding
+	Purge     key.Binding
+	Empty     key.Binding
+}
+
+// ShortHelp()
+func (k trashKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.GoBack, k.Restore, k.RestoreTo, k.Purge, k.Empty}
+}
+
+// FullHelp()
+func (k trashKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.GoBack},
+		{k.Restore},
+		{k.RestoreTo},
+		{k.Purge},
+		{k.Empty},
+	}
+}
+
+// Keys
+var trashKeys trashKeyMap
+
+// Trash screen
+type TrashScreen struct {
+	// Vault
+	vault *tlockvault.Vault
+
+	// Context
+	context *context.Context
+
+	// Listview
+	listview list.Model
+
+	// Whether any item was restored, so the folders need a refresh
+	changed bool
+
+	// Result of the last action, the status bar is not shown on this screen
+	status *components.StatusBarMsg
+}
+
+// Builds the items of the trash, the latest deleted first
+func buildTrashItems(vault *tlockvault.Vault) []list.Item {
+	items := make([]list.Item, 0, len(vault.Trash))
+
+	for index := len(vault.Trash) - 1; index >= 0; index-- {
+		items = append(items, trashListItem{item: vault.Trash[index], index: index})
+	}
+
+	return items
+}
+
+// Initialize trash screen
+func InitializeTrashScreen(vault *tlockvault.Vault, context *context.Context) TrashScreen {
+	// Initialize keys
+	trashKeys = trashKeyMap{
+		GoBack:    context.Config.Navigation.GoBack.WithHelp("go back"),
+		Restore:   context.Config.Navigation.Confirm.WithHelp("restore"),
+		RestoreTo: context.Config.Trash.RestoreTo.WithHelp("restore to"),
+		Purge:     context.Config.Trash.Purge.WithHelp("delete forever"),
+		Empty:     context.Config.Trash.Empty.WithHelp("empty trash"),
+	}
+
+	// Initialize listview
+	listview := components.ListViewSimple(buildTrashItems(vault), trashDelegate{}, trashWidth, 15)
+	listview.KeyMap.CursorUp = context.Config.Navigation.Up.Binding
+	listview.KeyMap.CursorDown = context.Config.Navigation.Down.Binding
+
+	// Unbind the paging keys (like d), they are used by the trash
+	listview.KeyMap.NextPage = key.NewBinding()
+	listview.KeyMap.PrevPage = key.NewBinding()
+
+	return TrashScreen{
+		vault:    vault,
+		context:  context,
+		listview: listview,
+	}
+}
+
+// Returns the focused item, nil if the trash is empty
+func (screen TrashScreen) focused() *trashListItem {
+	if len(screen.listview.Items()) == 0 {
+		return nil
+	}
+
+	item := screen.listview.Items()[screen.listview.Index()].(trashListItem)
+
+	return &item
+}
+
+// Init
+func (screen TrashScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update
+func (screen TrashScreen) Update(msg tea.Msg, manager *modelmanager.ModelManager) (modelmanager.Screen, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+
+	switch msgType := msg.(type) {
+	case tea.KeyMsg:
+		screen.status = nil
+
+		switch {
+		case key.Matches(msgType, trashKeys.GoBack):
+			// Show the restored items in the dashboard
+			if screen.changed {
+				cmds = append(cmds, tea.Sequence(
+					func() tea.Msg { return tlockmessages.RefreshFoldersMsg{} },
+					func() tea.Msg { return tlockmessages.RequestFolderChanged{} },
+				))
+			}
+
+			manager.PopScreen()
+
+		case key.Matches(msgType, trashKeys.Restore):
+			if focused := screen.focused(); focused != nil {
+				err := screen.vault.RestoreTrashItem(focused.index, focused.item.From)
+
+				// The folder may have been deleted too
+				if err == tlockvault.ERR_FOLDER_NOT_FOUND {
+					err = fmt.Errorf("%s is not there anymore, press %s to restore it to another folder", focused.item.From, screen.context.Config.Trash.RestoreTo.HelpKeys())
+				}
+
+				cmds = append(cmds, screen.restored(focused.item, focused.item.From, err))
+			}
+
+		case key.Matches(msgType, trashKeys.RestoreTo):
+			if focused := screen.focused(); focused != nil {
+				cmds = append(cmds, manager.PushScreen(InitializeRestoreToScreen(screen.vault, *focused, screen.context)))
+			}
+
+		case key.Matches(msgType, trashKeys.Purge):
+			if focused := screen.focused(); focused != nil {
+				cmds = append(cmds, manager.PushScreen(InitializePurgeScreen(screen.vault, focused, screen.context)))
+			}
+
+		case key.Matches(msgType, trashKeys.Empty):
+			if len(screen.listview.Items()) != 0 {
+				cmds = append(cmds, manager.PushScreen(InitializePurgeScreen(screen.vault, nil, screen.context)))
+			}
+		}
+
+	case components.StatusBarMsg:
+		screen.status = &msgType
+
+	case trashChangedMsg:
+		screen.changed = true
+		screen.refresh()
+
+	case trashPurgedMsg:
+		screen.refresh()
+	}
+
+	screen.listview, _ = screen.listview.Update(msg)
+
+	return screen, tea.Batch(cmds...)
+}
+
+// Reports the result of restoring the item, and refreshes the list if it was restored
+func (screen *TrashScreen) restored(item tlockvault.TrashItem, to string, err error) tea.Cmd {
+	if err != nil {
+		return func() tea.Msg {
+			return components.StatusBarMsg{Message: err.Error(), ErrorMessage: true}
+		}
+	}
+
+	screen.changed = true
+	screen.refresh()
+
+	return func() tea.Msg {
+		return components.StatusBarMsg{Message: restoredMessage(item, to)}
+	}
+}
+
+// Message for a restored item
+func restoredMessage(item tlockvault.TrashItem, to string) string {
+	if to == "" {
+		return fmt.Sprintf("Successfully restored %s to the top level", item.Name())
+	}
+
+	return fmt.Sprintf("Successfully restored %s to %s", item.Name(), to)
+}
+
+// Rebuilds the items after the trash changed
+func (screen *TrashScreen) refresh() {
+	screen.listview.SetItems(buildTrashItems(screen.vault))
+}
+
+// View
+func (screen TrashScreen) View() string {
+	desc := "Deleted tokens and folders, which can be restored"
+
+	if days := screen.context.Config.TrashRetentionDays; days > 0 {
+		desc = fmt.Sprintf("Deleted tokens and folders, which are kept for %d days", days)
+	}
+
+	content := screen.listview.View()
+
+	if len(screen.listview.Items()) == 0 {
+		content = tlockstyles.Dimmed("The trash is empty")
+	}
+
+	// Result of the last action
+	status := ""
+
+	if screen.status != nil && screen.status.ErrorMessage {
+		status = tlockstyles.Styles.Error.Render(screen.status.Message)
+	} else if screen.status != nil {
+		status = tlockstyles.Dimmed(screen.status.Message)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		tlockstyles.Title(trashAscii), "",
+		tlockstyles.Dimmed(desc), "",
+		content, "",
+		status, "",
+		tlockstyles.HelpView(trashKeys),
+	)
+}
@@ -1,11 +1,13 @@
 package tlockmodels
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/eklairs/tlock/tlock-internal/context"
 	"github.com/eklairs/tlock/tlock-internal/modelmanager"
 	"github.com/eklairs/tlock/tlock/models/auth"
+	"github.com/eklairs/tlock/tlock/models/dashboard"
 
 	tlockmessages "github.com/eklairs/tlock/tlock-internal/messages"
 )
@@ -13,6 +15,9 @@ import (
 // Root model
 type RootModel struct {
 	manager modelmanager.ModelManager
+
+	// Context
+	context *context.Context
 }
 
 // Initializes a new instance of the root model
@@ -28,12 +33,13 @@ func InitializeRootModel(context *context.Context) RootModel {
 
 	return RootModel{
 		manager: modelmanager.New(screen),
+		context: context,
 	}
 }
 
 // Init
 func (model RootModel) Init() tea.Cmd {
-	return nil
+	return model.manager.Init()
 }
 
 // Update
@@ -42,8 +48,7 @@ func (model RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "ctrl+q":
+		if key.Matches(msg, model.context.Config.Global.Quit.Binding) {
 			cmds = append(cmds, tea.Quit)
 		}
 
@@ -51,6 +56,9 @@ func (model RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// If a new screen is pushed to modelmanager, the dashboard will not recieve the message and thus will break the update
 	case tlockmessages.RefreshTokensValue:
 		cmds = append(cmds, tlockmessages.DispatchRefreshTokensValueMsg())
+
+		// Pick up the changes to the config, whichever screen is open
+		cmds = append(cmds, dashboard.ReloadConfig(model.context))
 	}
 
 	// Update model manager
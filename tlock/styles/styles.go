@@ -2,8 +2,10 @@ package tlockstyles
 
 import (
 	"github.com/charmbracelet/bubbles/help"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eklairs/tlock/tlock-internal/context"
+	"github.com/muesli/termenv"
 )
 
 // Help
@@ -65,6 +67,23 @@ type TLockStyles struct {
 
 	// Time left for inactive cards
 	TimeLeftInactive lipgloss.Style
+
+	// Active one line list item
+	ListRowActive lipgloss.Style
+
+	// Inactive one line list item
+	ListRowInactive lipgloss.Style
+}
+
+// Initializes the styles for the theme and changes the background color of the terminal
+func ApplyTheme(theme context.Theme) tea.Cmd {
+	// Reinitialize styles
+	InitializeStyles(theme)
+
+	// Change background color
+	return func() tea.Msg {
+		return tea.SetBackgroundColor(termenv.RGBColor(theme.Background))
+	}
 }
 
 // Initializes the styles
@@ -99,6 +118,8 @@ func InitializeStyles(theme context.Theme) {
 			BorderForeground(theme.Accent),
 		ListItemInactive: with(paddedItem),
 		TimeLeftInactive: with(base).Foreground(theme.BackgroundOver),
+		ListRowActive:    with(base).Padding(0, 3).Background(theme.BackgroundOver),
+		ListRowInactive:  with(base).Padding(0, 3),
 	}
 
 	// Initialize help